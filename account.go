@@ -0,0 +1,183 @@
+package polkassembly
+
+import "fmt"
+
+// LinkAddress links the signer's address to the authenticated user
+func (c *Client) LinkAddress(network string, signer Signer) error {
+	message := linkMessage(network, signer.Address(), signer.Address())
+
+	signature, err := signMessage(signer, message)
+	if err != nil {
+		return err
+	}
+
+	return c.LinkAddressWithSignature(LinkAddressRequest{
+		Address:   signer.Address(),
+		Message:   message,
+		Signature: signature,
+		Network:   network,
+	})
+}
+
+// LinkAddressWithSignature links an address using a signature produced elsewhere,
+// e.g. by a browser wallet
+func (c *Client) LinkAddressWithSignature(req LinkAddressRequest) error {
+	if req.Network == "" {
+		req.Network = c.network
+	}
+
+	r, err := c.client.R().
+		SetBody(req).
+		Post("/auth/link-address")
+
+	if err != nil {
+		return err
+	}
+
+	return c.parseResponse(r, nil)
+}
+
+func (c *Client) UnlinkAddress(address string) error {
+	r, err := c.client.R().
+		SetBody(map[string]string{"address": address}).
+		Post("/auth/unlink-address")
+
+	if err != nil {
+		return err
+	}
+
+	return c.parseResponse(r, nil)
+}
+
+// SetDefaultAddress selects which linked address is used by default for the user
+func (c *Client) SetDefaultAddress(address string) error {
+	r, err := c.client.R().
+		SetBody(map[string]string{"address": address}).
+		Post("/auth/set-default-address")
+
+	if err != nil {
+		return err
+	}
+
+	return c.parseResponse(r, nil)
+}
+
+// LinkWeb2Account adds email and password credentials to a web3-only user
+func (c *Client) LinkWeb2Account(req LinkWeb2AccountRequest) (*User, error) {
+	var resp Web2LoginResponse
+
+	r, err := c.client.R().
+		SetBody(req).
+		Post("/auth/link-web2-account")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.parseResponse(r, &resp); err != nil {
+		return nil, err
+	}
+
+	c.handleAuthResponse(resp.Token)
+	return &resp.User, nil
+}
+
+func (c *Client) SendVerificationEmail(email string) error {
+	r, err := c.client.R().
+		SetBody(map[string]string{"email": email}).
+		Post("/auth/send-verification-email")
+
+	if err != nil {
+		return err
+	}
+
+	return c.parseResponse(r, nil)
+}
+
+func (c *Client) VerifyEmail(token string) error {
+	r, err := c.client.R().
+		SetBody(map[string]string{"token": token}).
+		Post("/auth/verify-email")
+
+	if err != nil {
+		return err
+	}
+
+	return c.parseResponse(r, nil)
+}
+
+// UpdateEmail changes the user's email and requests a verification email for it
+func (c *Client) UpdateEmail(userID int, email string) (*User, error) {
+	user, err := c.EditUserDetails(userID, EditUserDetailsRequest{Email: email})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.SendVerificationEmail(email); err != nil {
+		return user, fmt.Errorf("send verification email: %w", err)
+	}
+
+	return user, nil
+}
+
+// Generate2FASecret starts 2FA setup and returns the TOTP secret to enrol
+func (c *Client) Generate2FASecret() (*TwoFactorSetup, error) {
+	var resp TwoFactorSetup
+
+	r, err := c.client.R().
+		Post("/auth/2fa/generate")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.parseResponse(r, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// Verify2FA confirms 2FA setup with a code from the authenticator app
+func (c *Client) Verify2FA(authCode string) error {
+	r, err := c.client.R().
+		SetBody(map[string]string{"authCode": authCode}).
+		Post("/auth/2fa/verify")
+
+	if err != nil {
+		return err
+	}
+
+	return c.parseResponse(r, nil)
+}
+
+func (c *Client) Disable2FA() error {
+	r, err := c.client.R().
+		Post("/auth/2fa/disable")
+
+	if err != nil {
+		return err
+	}
+
+	return c.parseResponse(r, nil)
+}
+
+// Validate2FA completes a Web2Login that returned IsTFAEnabled
+func (c *Client) Validate2FA(req Validate2FARequest) (*Web2LoginResponse, error) {
+	var resp Web2LoginResponse
+
+	r, err := c.client.R().
+		SetBody(req).
+		Post("/auth/2fa/validate")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.parseResponse(r, &resp); err != nil {
+		return nil, err
+	}
+
+	c.handleAuthResponse(resp.Token)
+	return &resp, nil
+}
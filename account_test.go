@@ -0,0 +1,226 @@
+package polkassembly
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type recordedRequest struct {
+	Method        string
+	Path          string
+	Authorization string
+	Body          map[string]interface{}
+}
+
+type stubResponse struct {
+	Status int
+	Body   interface{}
+}
+
+// newAccountTestClient returns a client for a server answering "METHOD /path"
+// with responses, by default 200 with an empty object, and the requests it
+// received
+func newAccountTestClient(t *testing.T, responses map[string]stubResponse) (*Client, *[]recordedRequest) {
+	t.Helper()
+
+	url, requests := newStubServer(t, responses)
+	return NewClient(Config{BaseURL: url, Network: "polkadot"}), requests
+}
+
+// newStubServer starts the server of newAccountTestClient and returns its URL
+func newStubServer(t *testing.T, responses map[string]stubResponse) (string, *[]recordedRequest) {
+	t.Helper()
+
+	var requests []recordedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := recordedRequest{Method: r.Method, Path: r.URL.Path, Authorization: r.Header.Get("Authorization")}
+		json.NewDecoder(r.Body).Decode(&req.Body)
+		requests = append(requests, req)
+
+		resp, ok := responses[r.Method+" "+r.URL.Path]
+		if !ok {
+			resp = stubResponse{Status: http.StatusOK, Body: map[string]interface{}{}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(resp.Status)
+		json.NewEncoder(w).Encode(resp.Body)
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL, &requests
+}
+
+type fixedSigner struct {
+	address string
+}
+
+func (s fixedSigner) Sign(message []byte) ([]byte, error) {
+	return []byte{0xab, 0xcd}, nil
+}
+
+func (s fixedSigner) Address() string {
+	return s.address
+}
+
+func TestLinkAddresses(t *testing.T) {
+	c, requests := newAccountTestClient(t, map[string]stubResponse{
+		"POST /auth/set-default-address": {Status: http.StatusBadRequest, Body: map[string]string{"message": "address is not linked"}},
+	})
+
+	if err := c.LinkAddress("kusama", fixedSigner{address: aliceAddress}); err != nil {
+		t.Fatalf("LinkAddress failed: %v", err)
+	}
+	if err := c.LinkAddressWithSignature(LinkAddressRequest{Address: bobAddress, Message: "msg", Signature: "0x01"}); err != nil {
+		t.Fatalf("LinkAddressWithSignature failed: %v", err)
+	}
+	if err := c.UnlinkAddress(bobAddress); err != nil {
+		t.Fatalf("UnlinkAddress failed: %v", err)
+	}
+
+	err := c.SetDefaultAddress(charlieAddress)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "address is not linked" {
+		t.Errorf("expected the API error, got %v", err)
+	}
+
+	if len(*requests) != 4 {
+		t.Fatalf("expected 4 requests, got %+v", *requests)
+	}
+	link := (*requests)[0]
+	if link.Method != http.MethodPost || link.Path != "/auth/link-address" {
+		t.Errorf("unexpected link request %s %s", link.Method, link.Path)
+	}
+	if link.Body["address"] != aliceAddress || link.Body["signature"] != "0xabcd" || link.Body["network"] != "kusama" {
+		t.Errorf("unexpected link body: %+v", link.Body)
+	}
+	if message, _ := link.Body["message"].(string); !strings.Contains(message, "Network: kusama") || !strings.Contains(message, aliceAddress) {
+		t.Errorf("unexpected link message: %q", message)
+	}
+	if withSignature := (*requests)[1]; withSignature.Body["network"] != "polkadot" || withSignature.Body["signature"] != "0x01" {
+		t.Errorf("expected the client network by default, got %+v", withSignature.Body)
+	}
+	if unlink := (*requests)[2]; unlink.Path != "/auth/unlink-address" || unlink.Body["address"] != bobAddress {
+		t.Errorf("unexpected unlink request: %+v", unlink)
+	}
+	if def := (*requests)[3]; def.Path != "/auth/set-default-address" || def.Body["address"] != charlieAddress {
+		t.Errorf("unexpected default address request: %+v", def)
+	}
+}
+
+func TestLinkWeb2Account(t *testing.T) {
+	c, requests := newAccountTestClient(t, map[string]stubResponse{
+		"POST /auth/link-web2-account": {Status: http.StatusOK, Body: Web2LoginResponse{
+			Token: "header.payload.signature",
+			User:  User{ID: 5, Username: "alice"},
+		}},
+	})
+
+	user, err := c.LinkWeb2Account(LinkWeb2AccountRequest{Email: "alice@example.com", Password: "secret"})
+	if err != nil {
+		t.Fatalf("LinkWeb2Account failed: %v", err)
+	}
+	if user.ID != 5 || user.Username != "alice" {
+		t.Errorf("unexpected user: %+v", user)
+	}
+	if body := (*requests)[0].Body; body["email"] != "alice@example.com" || body["password"] != "secret" {
+		t.Errorf("unexpected link body: %+v", body)
+	}
+	if _, hasUsername := (*requests)[0].Body["username"]; hasUsername {
+		t.Error("expected an empty username to be omitted")
+	}
+
+	// The returned token authenticates later requests
+	if err := c.Disable2FA(); err != nil {
+		t.Fatal(err)
+	}
+	if auth := (*requests)[1].Authorization; auth != "Bearer header.payload.signature" {
+		t.Errorf("unexpected authorization header %q", auth)
+	}
+}
+
+func TestEmailVerification(t *testing.T) {
+	c, requests := newAccountTestClient(t, map[string]stubResponse{
+		"PATCH /users/id/5":                  {Status: http.StatusOK, Body: User{ID: 5, Email: "new@example.com"}},
+		"POST /auth/send-verification-email": {Status: http.StatusTooManyRequests, Body: map[string]string{"error": "too many requests"}},
+	})
+
+	if err := c.VerifyEmail("token-1"); err != nil {
+		t.Fatalf("VerifyEmail failed: %v", err)
+	}
+	if verify := (*requests)[0]; verify.Path != "/auth/verify-email" || verify.Body["token"] != "token-1" {
+		t.Errorf("unexpected verify request: %+v", verify)
+	}
+
+	// The email is updated even when the verification email cannot be sent
+	user, err := c.UpdateEmail(5, "new@example.com")
+	if user == nil || user.Email != "new@example.com" {
+		t.Errorf("expected the updated user, got %+v", user)
+	}
+	if err == nil || !strings.Contains(err.Error(), "too many requests") {
+		t.Errorf("expected the verification email error, got %v", err)
+	}
+
+	if len(*requests) != 3 {
+		t.Fatalf("expected 3 requests, got %+v", *requests)
+	}
+	if edit := (*requests)[1]; edit.Method != http.MethodPatch || edit.Body["email"] != "new@example.com" {
+		t.Errorf("unexpected edit request: %+v", edit)
+	}
+	if send := (*requests)[2]; send.Path != "/auth/send-verification-email" || send.Body["email"] != "new@example.com" {
+		t.Errorf("unexpected send request: %+v", send)
+	}
+}
+
+func TestTwoFactorAuth(t *testing.T) {
+	c, requests := newAccountTestClient(t, map[string]stubResponse{
+		"POST /auth/2fa/generate": {Status: http.StatusOK, Body: TwoFactorSetup{Base32Secret: "JBSWY3DP", URL: "otpauth://totp/alice"}},
+		"POST /auth/2fa/verify":   {Status: http.StatusUnauthorized, Body: map[string]string{"message": "invalid code"}},
+		"POST /auth/2fa/validate": {Status: http.StatusOK, Body: Web2LoginResponse{Token: "session-token", User: User{ID: 5}}},
+	})
+
+	setup, err := c.Generate2FASecret()
+	if err != nil {
+		t.Fatalf("Generate2FASecret failed: %v", err)
+	}
+	if setup.Base32Secret != "JBSWY3DP" || setup.URL != "otpauth://totp/alice" {
+		t.Errorf("unexpected setup: %+v", setup)
+	}
+
+	if err := c.Verify2FA("000000"); err == nil || err.Error() != "invalid code" {
+		t.Errorf("expected the invalid code error, got %v", err)
+	}
+
+	resp, err := c.Validate2FA(Validate2FARequest{TFAToken: "tfa", AuthCode: "123456", LoginID: 5})
+	if err != nil {
+		t.Fatalf("Validate2FA failed: %v", err)
+	}
+	if resp.Token != "session-token" || c.token != "session-token" {
+		t.Errorf("expected the session token to be set, got %q and %q", resp.Token, c.token)
+	}
+
+	if err := c.Disable2FA(); err != nil {
+		t.Fatalf("Disable2FA failed: %v", err)
+	}
+
+	paths := make([]string, len(*requests))
+	for i, r := range *requests {
+		paths[i] = r.Path
+	}
+	if strings.Join(paths, " ") != "/auth/2fa/generate /auth/2fa/verify /auth/2fa/validate /auth/2fa/disable" {
+		t.Errorf("unexpected paths: %v", paths)
+	}
+	if verify := (*requests)[1]; verify.Body["authCode"] != "000000" {
+		t.Errorf("unexpected verify body: %+v", verify.Body)
+	}
+	validate := (*requests)[2]
+	if validate.Body["tfaToken"] != "tfa" || validate.Body["authCode"] != "123456" || validate.Body["loginId"] != float64(5) {
+		t.Errorf("unexpected validate body: %+v", validate.Body)
+	}
+	if auth := (*requests)[3].Authorization; auth != "session-token" {
+		t.Errorf("unexpected authorization header %q", auth)
+	}
+}
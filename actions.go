@@ -5,9 +5,14 @@ import (
 	"strings"
 )
 
-func (c *Client) AddComment(proposalType string, postID int, req AddCommentRequest) (*Comment, error) {
+func (c *Client) AddComment(proposalType ProposalType, postID int, req AddCommentRequest) (*Comment, error) {
 	var resp Comment
-	endpoint := fmt.Sprintf("/%s/%d/comments", proposalType, postID)
+	path, err := proposalPath(proposalType, ProposalTypeReferendumV2)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("/%s/%d/comments", path, postID)
 
 	body := map[string]interface{}{
 		"content": req.Content,
@@ -36,9 +41,14 @@ func (c *Client) AddComment(proposalType string, postID int, req AddCommentReque
 	return &resp, nil
 }
 
-func (c *Client) UpdateComment(proposalType string, postID int, commentID string, content interface{}) (*Comment, error) {
+func (c *Client) UpdateComment(proposalType ProposalType, postID int, commentID string, content interface{}) (*Comment, error) {
 	var resp Comment
-	endpoint := fmt.Sprintf("/%s/%d/comments/%s", proposalType, postID, commentID)
+	path, err := proposalPath(proposalType, ProposalTypeReferendumV2)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("/%s/%d/comments/%s", path, postID, commentID)
 
 	r, err := c.client.R().
 		SetBody(map[string]interface{}{
@@ -65,9 +75,14 @@ func (c *Client) UpdateComment(proposalType string, postID int, commentID string
 	return &resp, nil
 }
 
-func (c *Client) AddReaction(proposalType string, postID int, reaction string) (*Reaction, error) {
+func (c *Client) AddReaction(proposalType ProposalType, postID int, reaction string) (*Reaction, error) {
 	var resp Reaction
-	endpoint := fmt.Sprintf("/%s/%d/reactions", proposalType, postID)
+	path, err := proposalPath(proposalType, ProposalTypeReferendumV2)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("/%s/%d/reactions", path, postID)
 
 	r, err := c.client.R().
 		SetBody(map[string]interface{}{
@@ -102,8 +117,13 @@ func (c *Client) AddReaction(proposalType string, postID int, reaction string) (
 	return &resp, nil
 }
 
-func (c *Client) DeleteComment(proposalType string, postID int, commentID string) error {
-	endpoint := fmt.Sprintf("/%s/%d/comments/%s", proposalType, postID, commentID)
+func (c *Client) DeleteComment(proposalType ProposalType, postID int, commentID string) error {
+	path, err := proposalPath(proposalType, ProposalTypeReferendumV2)
+	if err != nil {
+		return err
+	}
+
+	endpoint := fmt.Sprintf("/%s/%d/comments/%s", path, postID, commentID)
 
 	r, err := c.client.R().
 		Delete(endpoint)
@@ -115,9 +135,14 @@ func (c *Client) DeleteComment(proposalType string, postID int, commentID string
 	return c.parseResponse(r, nil)
 }
 
-func (c *Client) DeleteReaction(proposalType string, postID int, reactionID string) error {
+func (c *Client) DeleteReaction(proposalType ProposalType, postID int, reactionID string) error {
+	path, err := proposalPath(proposalType, ProposalTypeReferendumV2)
+	if err != nil {
+		return err
+	}
+
 	// API might not support DELETE with ID, try removing reaction by type
-	endpoint := fmt.Sprintf("/%s/%d/reactions", proposalType, postID)
+	endpoint := fmt.Sprintf("/%s/%d/reactions", path, postID)
 
 	// Extract reaction type from ID if it's our temp format
 	var reaction string
@@ -166,9 +191,14 @@ func (c *Client) UnfollowUser(userID int) error {
 	return c.parseResponse(r, nil)
 }
 
-func (c *Client) SubscribeProposal(proposalType string, postID int) error {
+func (c *Client) SubscribeProposal(proposalType ProposalType, postID int) error {
+	path, err := proposalPath(proposalType, ProposalTypeReferendumV2)
+	if err != nil {
+		return err
+	}
+
 	r, err := c.client.R().
-		Post(fmt.Sprintf("/%s/%d/subscription", proposalType, postID))
+		Post(fmt.Sprintf("/%s/%d/subscription", path, postID))
 
 	if err != nil {
 		return err
@@ -177,9 +207,14 @@ func (c *Client) SubscribeProposal(proposalType string, postID int) error {
 	return c.parseResponse(r, nil)
 }
 
-func (c *Client) UnsubscribeProposal(proposalType string, postID int) error {
+func (c *Client) UnsubscribeProposal(proposalType ProposalType, postID int) error {
+	path, err := proposalPath(proposalType, ProposalTypeReferendumV2)
+	if err != nil {
+		return err
+	}
+
 	r, err := c.client.R().
-		Delete(fmt.Sprintf("/%s/%d/subscription", proposalType, postID))
+		Delete(fmt.Sprintf("/%s/%d/subscription", path, postID))
 
 	if err != nil {
 		return err
@@ -0,0 +1,94 @@
+package polkassembly
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/polkadot-go/polkassembly-api/scale"
+	"github.com/vedhavyas/go-subkey/v2"
+	"golang.org/x/crypto/blake2b"
+)
+
+// ss58Prefix maps a network name to its SS58 address prefix
+func ss58Prefix(network string) uint16 {
+	switch network {
+	case "polkadot":
+		return 0
+	case "kusama":
+		return 2
+	default:
+		return 42
+	}
+}
+
+// AccountID decodes an SS58 address into its 32-byte public key
+func AccountID(address string) ([]byte, error) {
+	_, pub, err := subkey.SS58Decode(address)
+	if err != nil {
+		return nil, fmt.Errorf("decode address %s: %w", address, err)
+	}
+	if len(pub) != 32 {
+		return nil, fmt.Errorf("unexpected account id length for %s: %d", address, len(pub))
+	}
+	return pub, nil
+}
+
+// SameAccount reports whether two SS58 addresses encode the same account,
+// regardless of network prefix
+func SameAccount(a, b string) bool {
+	if a == b {
+		return true
+	}
+	pubA, err := AccountID(a)
+	if err != nil {
+		return false
+	}
+	pubB, err := AccountID(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(pubA, pubB)
+}
+
+// accountKey normalizes an address for use as a map key so the same account
+// matches across SS58 prefixes. Undecodable addresses are used as-is.
+func accountKey(address string) string {
+	if id, err := AccountID(address); err == nil {
+		return hex.EncodeToString(id)
+	}
+	return address
+}
+
+// MultisigAddress derives the address of a multisig account the same way
+// pallet-multisig does: blake2_256("modlpy/utilisuba" ++ sorted signatories ++ threshold)
+func MultisigAddress(signatories []string, threshold int, ss58Format uint16) (string, error) {
+	if threshold < 1 || threshold > len(signatories) {
+		return "", fmt.Errorf("invalid multisig threshold %d for %d signatories", threshold, len(signatories))
+	}
+
+	ids := make([][]byte, 0, len(signatories))
+	for _, s := range signatories {
+		id, err := AccountID(s)
+		if err != nil {
+			return "", err
+		}
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return bytes.Compare(ids[i], ids[j]) < 0
+	})
+
+	var buf bytes.Buffer
+	buf.WriteString("modlpy/utilisuba")
+	buf.Write(scale.CompactLength(len(ids)))
+	for _, id := range ids {
+		buf.Write(id)
+	}
+	binary.Write(&buf, binary.LittleEndian, uint16(threshold))
+
+	hash := blake2b.Sum256(buf.Bytes())
+	return subkey.SS58Encode(hash[:], ss58Format), nil
+}
@@ -0,0 +1,25 @@
+package polkassembly
+
+import "testing"
+
+const (
+	aliceAddress   = "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"
+	bobAddress     = "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty"
+	charlieAddress = "5FLSigC9HGRKVhB9FiEo4Y3koPsNmBmLJbpXg2mp1hXcS59Y"
+)
+
+func TestMultisigAddress(t *testing.T) {
+	address, err := MultisigAddress([]string{charlieAddress, aliceAddress, bobAddress}, 2, 42)
+	if err != nil {
+		t.Fatalf("MultisigAddress failed: %v", err)
+	}
+
+	expected := "5DjYJStmdZ2rcqXbXGX7TW85JsrW6uG4y9MUcLq2BoPMpRA7"
+	if address != expected {
+		t.Errorf("expected %s, got %s", expected, address)
+	}
+
+	if _, err := MultisigAddress([]string{aliceAddress}, 2, 42); err == nil {
+		t.Error("expected error for threshold above signatory count")
+	}
+}
@@ -0,0 +1,203 @@
+package polkassembly
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// topCommentersLimit is the number of commenters kept by BuildProposalAnalytics
+const topCommentersLimit = 10
+
+// GetProposalAnalytics retrieves the analytics of a post. When the server
+// endpoint is unavailable they are estimated from comments and votes and
+// marked Approximate.
+func (c *Client) GetProposalAnalytics(proposalType ProposalType, postID int) (*ProposalAnalytics, error) {
+	path, err := proposalPath(proposalType, ProposalTypeReferendumV2)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := c.client.R().
+		Get(fmt.Sprintf("/%s/%d/analytics", path, postID))
+	if err != nil {
+		return nil, err
+	}
+	if endpointUnavailable(r) {
+		c.logDebug("Analytics endpoint unavailable (HTTP %d), estimating", r.StatusCode())
+		return c.EstimateProposalAnalytics(proposalType, postID)
+	}
+
+	var resp ProposalAnalytics
+	if err := c.parseResponse(r, &resp); err != nil {
+		return nil, err
+	}
+	if resp.PostID == 0 {
+		resp.PostID = postID
+	}
+	return &resp, nil
+}
+
+// EstimateProposalAnalytics computes approximate analytics for a post from
+// its comments and, for on-chain posts, its votes. Views are taken from the
+// post metrics and shares are not available.
+func (c *Client) EstimateProposalAnalytics(proposalType ProposalType, postID int) (*ProposalAnalytics, error) {
+	if proposalType == "" {
+		proposalType = ProposalTypeReferendumV2
+	}
+
+	post, err := c.GetPostByType(postID, proposalType)
+	if err != nil {
+		return nil, fmt.Errorf("get post %d: %w", postID, err)
+	}
+	comments, err := c.GetPostCommentsByType(postID, proposalType)
+	if err != nil {
+		return nil, fmt.Errorf("get comments of post %d: %w", postID, err)
+	}
+
+	var votes []Vote
+	if !proposalType.IsOffChain() {
+		if votes, err = c.GetAllVotes(postID, proposalType); err != nil {
+			return nil, fmt.Errorf("get votes of post %d: %w", postID, err)
+		}
+	}
+
+	analytics := BuildProposalAnalytics(post, comments, votes)
+	if analytics.PostID == 0 {
+		analytics.PostID = postID
+	}
+	return analytics, nil
+}
+
+// BuildProposalAnalytics summarizes a post with its comments and votes. The
+// daily series is per UTC day and has no views.
+func BuildProposalAnalytics(post *Post, comments []Comment, votes []Vote) *ProposalAnalytics {
+	a := &ProposalAnalytics{
+		VoteCount:      len(votes),
+		VoterBreakdown: make(map[string]int),
+		Approximate:    true,
+	}
+	if post != nil {
+		a.PostID = post.Index
+		if a.PostID == 0 {
+			a.PostID = post.PostID
+		}
+		a.ViewCount = post.ViewsCount
+		a.ReactionCount = post.ReactionsCount
+		if a.ReactionCount == 0 {
+			a.ReactionCount = post.Metrics.Reactions.Like + post.Metrics.Reactions.Dislike
+		}
+	}
+
+	days := make(map[time.Time]*DailyStat)
+	day := func(t time.Time) *DailyStat {
+		d := t.UTC().Truncate(24 * time.Hour)
+		if days[d] == nil {
+			days[d] = &DailyStat{Date: d}
+		}
+		return days[d]
+	}
+
+	byUser := make(map[string]int)
+	for _, comment := range flattenComments(comments) {
+		if comment.IsDeleted {
+			continue
+		}
+		a.CommentCount++
+		if comment.Username != "" {
+			byUser[comment.Username]++
+		}
+		if !comment.CreatedAt.IsZero() {
+			day(comment.CreatedAt).Comments++
+		}
+	}
+
+	for _, v := range votes {
+		decision := v.Decision
+		if decision == "" {
+			decision = v.Vote
+		}
+		a.VoterBreakdown[decision]++
+		if !v.CreatedAt.IsZero() {
+			day(v.CreatedAt).Votes++
+		}
+	}
+
+	for username, count := range byUser {
+		a.TopCommenters = append(a.TopCommenters, UserStat{Username: username, Count: count})
+	}
+	sort.Slice(a.TopCommenters, func(i, j int) bool {
+		if a.TopCommenters[i].Count != a.TopCommenters[j].Count {
+			return a.TopCommenters[i].Count > a.TopCommenters[j].Count
+		}
+		return a.TopCommenters[i].Username < a.TopCommenters[j].Username
+	})
+	if len(a.TopCommenters) > topCommentersLimit {
+		a.TopCommenters = a.TopCommenters[:topCommentersLimit]
+	}
+
+	for _, d := range days {
+		a.DailyStats = append(a.DailyStats, *d)
+	}
+	sort.Slice(a.DailyStats, func(i, j int) bool {
+		return a.DailyStats[i].Date.Before(a.DailyStats[j].Date)
+	})
+	return a
+}
+
+// GetNetworkStats retrieves network-wide stats for network, or for the
+// client network when network is empty
+func (c *Client) GetNetworkStats(network string) (*NetworkStats, error) {
+	req := c.client.R()
+	if network != "" {
+		req.SetHeader("x-network", network)
+	}
+
+	r, err := req.Get("/stats")
+	if err != nil {
+		return nil, err
+	}
+
+	var raw json.RawMessage
+	if err := c.parseResponse(r, &raw); err != nil {
+		return nil, err
+	}
+
+	// Some deployments wrap the stats in a data field
+	var wrapped struct {
+		Data *NetworkStats `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &wrapped); err == nil && wrapped.Data != nil {
+		return wrapped.Data, nil
+	}
+
+	var resp NetworkStats
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal network stats: %w", err)
+	}
+	return &resp, nil
+}
+
+// flattenComments returns comments with their replies, depth first
+func flattenComments(comments []Comment) []Comment {
+	var all []Comment
+	for _, comment := range comments {
+		all = append(all, comment)
+		all = append(all, flattenComments(comment.Replies)...)
+		all = append(all, flattenComments(comment.Children)...)
+	}
+	return all
+}
+
+// endpointUnavailable reports whether a response means the endpoint does not
+// exist or is down, as opposed to a request error
+func endpointUnavailable(r *resty.Response) bool {
+	switch r.StatusCode() {
+	case 404, 405, 501, 502, 503:
+		return true
+	}
+	return false
+}
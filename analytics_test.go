@@ -0,0 +1,48 @@
+package polkassembly
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildProposalAnalytics(t *testing.T) {
+	day1 := time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+
+	post := &Post{Index: 42, ViewsCount: 100}
+	post.Metrics.Reactions.Like = 4
+	post.Metrics.Reactions.Dislike = 1
+
+	comments := []Comment{
+		{Username: "alice", CreatedAt: day1, Replies: []Comment{
+			{Username: "bob", CreatedAt: day2},
+			{Username: "alice", CreatedAt: day2},
+		}},
+		{Username: "carol", CreatedAt: day2, IsDeleted: true},
+	}
+	votes := []Vote{
+		{Decision: "aye", CreatedAt: day1},
+		{Decision: "aye", CreatedAt: day2},
+		{Decision: "nay", CreatedAt: day2},
+	}
+
+	a := BuildProposalAnalytics(post, comments, votes)
+	if a.PostID != 42 || a.ViewCount != 100 || a.ReactionCount != 5 || !a.Approximate {
+		t.Errorf("unexpected analytics: %+v", a)
+	}
+	if a.CommentCount != 3 || a.VoteCount != 3 || a.VoterBreakdown["aye"] != 2 || a.VoterBreakdown["nay"] != 1 {
+		t.Errorf("unexpected counts: comments %d, votes %d, breakdown %v", a.CommentCount, a.VoteCount, a.VoterBreakdown)
+	}
+	if len(a.TopCommenters) != 2 || a.TopCommenters[0] != (UserStat{Username: "alice", Count: 2}) {
+		t.Errorf("unexpected top commenters: %+v", a.TopCommenters)
+	}
+	if len(a.DailyStats) != 2 {
+		t.Fatalf("expected 2 days, got %+v", a.DailyStats)
+	}
+	if d := a.DailyStats[0]; !d.Date.Equal(day1.Truncate(24*time.Hour)) || d.Comments != 1 || d.Votes != 1 {
+		t.Errorf("unexpected first day: %+v", d)
+	}
+	if d := a.DailyStats[1]; d.Comments != 2 || d.Votes != 2 {
+		t.Errorf("unexpected second day: %+v", d)
+	}
+}
@@ -0,0 +1,90 @@
+package polkassembly
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+)
+
+// Asset is a token a treasury can spend. An empty ID is the native token.
+type Asset struct {
+	ID       string `json:"id"`
+	Symbol   string `json:"symbol"`
+	Decimals int    `json:"decimals"`
+}
+
+var (
+	assetsMu sync.RWMutex
+	assets   = map[string][]Asset{
+		"polkadot": {
+			{Symbol: "DOT", Decimals: 10},
+			{ID: "1984", Symbol: "USDT", Decimals: 6},
+			{ID: "1337", Symbol: "USDC", Decimals: 6},
+		},
+		"kusama": {
+			{Symbol: "KSM", Decimals: 12},
+			{ID: "1984", Symbol: "USDT", Decimals: 6},
+		},
+	}
+)
+
+// ErrUnknownAsset is wrapped by the errors for assets missing from the registry
+var ErrUnknownAsset = errors.New("unknown asset")
+
+// RegisterAsset adds an asset to the registry of network, replacing any asset with the same ID
+func RegisterAsset(network string, asset Asset) {
+	assetsMu.Lock()
+	defer assetsMu.Unlock()
+
+	for i, a := range assets[network] {
+		if a.ID == asset.ID {
+			assets[network][i] = asset
+			return
+		}
+	}
+	assets[network] = append(assets[network], asset)
+}
+
+// AssetByID looks up an asset by its asset hub ID, or the native token when id is empty
+func AssetByID(network, id string) (*Asset, error) {
+	id = strings.TrimSpace(id)
+
+	assetsMu.RLock()
+	defer assetsMu.RUnlock()
+	for _, a := range assets[network] {
+		if a.ID == id {
+			return &a, nil
+		}
+	}
+	if id == "" {
+		return nil, fmt.Errorf("%w: no native asset on %s", ErrUnknownAsset, network)
+	}
+	return nil, fmt.Errorf("%w %s on %s", ErrUnknownAsset, id, network)
+}
+
+// NativeAsset returns the native token of network
+func NativeAsset(network string) (*Asset, error) {
+	return AssetByID(network, "")
+}
+
+// FormatUnits renders an amount in the smallest unit as a decimal number of
+// whole tokens, e.g. 15000000000 with 10 decimals is "1.5"
+func FormatUnits(amount *big.Int, decimals int) string {
+	s := new(big.Rat).SetFrac(amount, pow10(decimals)).FloatString(decimals)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+// toUnits converts an amount in the smallest unit to whole tokens
+func toUnits(amount *big.Int, decimals int) float64 {
+	f, _ := new(big.Rat).SetFrac(amount, pow10(decimals)).Float64()
+	return f
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
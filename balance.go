@@ -0,0 +1,38 @@
+package polkassembly
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ParseBalance parses a balance string as returned by the API. Balances may
+// be decimal or 0x-prefixed hex; an empty string is zero.
+func ParseBalance(s string) (*big.Int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return new(big.Int), nil
+	}
+
+	v := new(big.Int)
+	var ok bool
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		_, ok = v.SetString(s[2:], 16)
+	} else {
+		_, ok = v.SetString(s, 10)
+	}
+	if !ok {
+		return nil, fmt.Errorf("invalid balance: %q", s)
+	}
+
+	return v, nil
+}
+
+// ratio returns a/b as a float64, or 0 when b is zero
+func ratio(a, b *big.Int) float64 {
+	if b.Sign() == 0 {
+		return 0
+	}
+	f, _ := new(big.Rat).SetFrac(a, b).Float64()
+	return f
+}
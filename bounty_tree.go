@@ -0,0 +1,198 @@
+package polkassembly
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+)
+
+// On-chain bounty and child bounty statuses
+const (
+	BountyStatusProposed        = "Proposed"
+	BountyStatusApproved        = "Approved"
+	BountyStatusFunded          = "Funded"
+	BountyStatusAdded           = "Added"
+	BountyStatusCuratorProposed = "CuratorProposed"
+	BountyStatusActive          = "Active"
+	BountyStatusPendingPayout   = "PendingPayout"
+	BountyStatusClaimed         = "Claimed"
+	BountyStatusCanceled        = "Canceled"
+	BountyStatusRejected        = "Rejected"
+)
+
+// BountyTree is a parent bounty with its child bounties and their rollup.
+// Amounts are decimal strings in the smallest unit.
+type BountyTree struct {
+	Bounty   Bounty            `json:"bounty"`
+	Children []ChildBountyNode `json:"children"`
+	Rollup   BountyRollup      `json:"rollup"`
+}
+
+// ChildBountyNode is a child bounty with its payout and timeline
+type ChildBountyNode struct {
+	Bounty Bounty `json:"bounty"`
+	// Payout is the value less the curator fee, paid to the beneficiary once
+	// the child bounty is awarded. Empty until then.
+	Payout   string              `json:"payout,omitempty"`
+	Timeline []BountyStatusEvent `json:"timeline"`
+	// ClaimedAfter is the time from the first timeline entry to the claim
+	ClaimedAfter time.Duration `json:"claimedAfter,omitempty"`
+}
+
+// BountyRollup sums the child bounties of a parent bounty. Allocated counts
+// every child that is not canceled, Awarded those pending payout or claimed.
+type BountyRollup struct {
+	Budget    string         `json:"budget"`
+	Allocated string         `json:"allocated"`
+	Awarded   string         `json:"awarded"`
+	Claimed   string         `json:"claimed"`
+	Fees      string         `json:"fees"`
+	Remaining string         `json:"remaining"`
+	ByStatus  map[string]int `json:"byStatus"`
+	// ByCurator sums the awarded value per child bounty curator
+	ByCurator map[string]string `json:"byCurator,omitempty"`
+}
+
+// BuildBountyTree combines a parent bounty with its child bounties. Children
+// of other parents are skipped; unparsable values are an error.
+func BuildBountyTree(parent Bounty, children []Bounty) (*BountyTree, error) {
+	budget, err := ParseBalance(parent.Value)
+	if err != nil {
+		return nil, fmt.Errorf("bounty %d: value: %w", parent.BountyID, err)
+	}
+
+	allocated, awarded, claimed, fees := new(big.Int), new(big.Int), new(big.Int), new(big.Int)
+	// Curators are keyed by account so different SS58 prefixes are merged
+	byCurator, curators := make(map[string]*big.Int), make(map[string]string)
+	tree := &BountyTree{Bounty: parent, Rollup: BountyRollup{ByStatus: make(map[string]int)}}
+
+	for _, child := range children {
+		if child.ParentBountyID != 0 && child.ParentBountyID != parent.BountyID {
+			continue
+		}
+		value, err := ParseBalance(child.Value)
+		if err != nil {
+			return nil, fmt.Errorf("child bounty %d: value: %w", child.BountyID, err)
+		}
+		fee, err := ParseBalance(child.Fee)
+		if err != nil {
+			return nil, fmt.Errorf("child bounty %d: fee: %w", child.BountyID, err)
+		}
+
+		node := ChildBountyNode{Bounty: child, Timeline: BountyTimeline(child)}
+		tree.Rollup.ByStatus[child.Status]++
+
+		if !bountyCanceled(child.Status) {
+			allocated.Add(allocated, value)
+		}
+		if bountyAwarded(child.Status) {
+			awarded.Add(awarded, value)
+			fees.Add(fees, fee)
+			node.Payout = new(big.Int).Sub(value, fee).String()
+
+			if child.Curator != "" {
+				key := accountKey(child.Curator)
+				if byCurator[key] == nil {
+					byCurator[key] = new(big.Int)
+					curators[key] = child.Curator
+				}
+				byCurator[key].Add(byCurator[key], value)
+			}
+		}
+		if strings.EqualFold(child.Status, BountyStatusClaimed) {
+			claimed.Add(claimed, value)
+			node.ClaimedAfter = claimDelay(node.Timeline)
+		}
+		tree.Children = append(tree.Children, node)
+	}
+
+	sort.Slice(tree.Children, func(i, j int) bool {
+		return tree.Children[i].Bounty.BountyID < tree.Children[j].Bounty.BountyID
+	})
+
+	tree.Rollup.Budget = budget.String()
+	tree.Rollup.Allocated = allocated.String()
+	tree.Rollup.Awarded = awarded.String()
+	tree.Rollup.Claimed = claimed.String()
+	tree.Rollup.Fees = fees.String()
+	tree.Rollup.Remaining = new(big.Int).Sub(budget, allocated).String()
+	if len(byCurator) > 0 {
+		tree.Rollup.ByCurator = make(map[string]string, len(byCurator))
+		for key, amount := range byCurator {
+			tree.Rollup.ByCurator[curators[key]] = amount.String()
+		}
+	}
+	return tree, nil
+}
+
+// BountyTimeline returns the status history of a bounty in time order. Without
+// a history it is the creation time with the current status.
+func BountyTimeline(b Bounty) []BountyStatusEvent {
+	if len(b.StatusHistory) == 0 {
+		return []BountyStatusEvent{{Status: b.Status, Timestamp: b.CreatedAt}}
+	}
+	timeline := append([]BountyStatusEvent(nil), b.StatusHistory...)
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].Timestamp.Before(timeline[j].Timestamp)
+	})
+	return timeline
+}
+
+// Overcommitted reports whether the children allocate more than the budget
+func (r BountyRollup) Overcommitted() bool {
+	return strings.HasPrefix(r.Remaining, "-")
+}
+
+// childBountyPageSize is the page size GetBountyTree lists child bounties with
+const childBountyPageSize = 100
+
+// GetBountyTree loads a bounty with all of its child bounties, paging through
+// them until a short page
+func (c *Client) GetBountyTree(bountyID int) (*BountyTree, error) {
+	parent, err := c.GetBounty(bountyID)
+	if err != nil {
+		return nil, fmt.Errorf("get bounty %d: %w", bountyID, err)
+	}
+	if parent.BountyID == 0 {
+		parent.BountyID = bountyID
+	}
+
+	var children []Bounty
+	for page := 1; ; page++ {
+		batch, err := c.ListChildBounties(bountyID, BountyListingParams{Page: page, Limit: childBountyPageSize})
+		if err != nil {
+			return nil, fmt.Errorf("get child bounties of %d: %w", bountyID, err)
+		}
+		children = append(children, batch...)
+		if len(batch) < childBountyPageSize {
+			break
+		}
+	}
+	c.logDebug("Bounty %d has %d child bounties", bountyID, len(children))
+
+	return BuildBountyTree(*parent, children)
+}
+
+func bountyCanceled(status string) bool {
+	return strings.EqualFold(status, BountyStatusCanceled) || strings.EqualFold(status, "Cancelled")
+}
+
+func bountyAwarded(status string) bool {
+	return strings.EqualFold(status, BountyStatusPendingPayout) || strings.EqualFold(status, BountyStatusClaimed)
+}
+
+// claimDelay is the time from the first timeline entry to the claim, or zero
+// when the claim has no timestamp
+func claimDelay(timeline []BountyStatusEvent) time.Duration {
+	if len(timeline) == 0 || timeline[0].Timestamp.IsZero() {
+		return 0
+	}
+	for _, e := range timeline {
+		if strings.EqualFold(e.Status, BountyStatusClaimed) && !e.Timestamp.IsZero() {
+			return e.Timestamp.Sub(timeline[0].Timestamp)
+		}
+	}
+	return 0
+}
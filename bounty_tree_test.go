@@ -0,0 +1,96 @@
+package polkassembly
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestBuildBountyTree(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	parent := Bounty{BountyID: 10, Value: "1000", Status: BountyStatusActive}
+	children := []Bounty{
+		{BountyID: 3, ParentBountyID: 10, Value: "300", Fee: "30", Status: BountyStatusClaimed, Curator: aliceAddress,
+			StatusHistory: []BountyStatusEvent{
+				{Status: BountyStatusClaimed, Timestamp: start.Add(72 * time.Hour)},
+				{Status: BountyStatusAdded, Timestamp: start},
+			}},
+		{BountyID: 1, ParentBountyID: 10, Value: "200", Fee: "0", Status: BountyStatusPendingPayout, Curator: aliceAddress},
+		{BountyID: 2, ParentBountyID: 10, Value: "0x64", Status: BountyStatusActive},
+		{BountyID: 4, ParentBountyID: 10, Value: "500", Status: BountyStatusCanceled},
+		{BountyID: 5, ParentBountyID: 11, Value: "999", Status: BountyStatusActive},
+	}
+
+	tree, err := BuildBountyTree(parent, children)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tree.Children) != 4 || tree.Children[0].Bounty.BountyID != 1 {
+		t.Fatalf("unexpected children: %+v", tree.Children)
+	}
+
+	r := tree.Rollup
+	if r.Allocated != "600" || r.Awarded != "500" || r.Claimed != "300" || r.Fees != "30" || r.Remaining != "400" {
+		t.Errorf("unexpected rollup: %+v", r)
+	}
+	if r.ByStatus[BountyStatusActive] != 1 || r.ByStatus[BountyStatusCanceled] != 1 {
+		t.Errorf("unexpected status counts: %v", r.ByStatus)
+	}
+	if r.ByCurator[aliceAddress] != "500" {
+		t.Errorf("expected 500 awarded to alice's child bounties, got %v", r.ByCurator)
+	}
+
+	claimed := tree.Children[2]
+	if claimed.Payout != "270" || claimed.ClaimedAfter != 72*time.Hour {
+		t.Errorf("unexpected claimed child: payout %s after %s", claimed.Payout, claimed.ClaimedAfter)
+	}
+	if claimed.Timeline[0].Status != BountyStatusAdded {
+		t.Errorf("timeline not sorted: %+v", claimed.Timeline)
+	}
+	if tree.Children[1].Payout != "" {
+		t.Errorf("active child should have no payout, got %s", tree.Children[1].Payout)
+	}
+
+	over, err := BuildBountyTree(Bounty{BountyID: 10, Value: "100"}, children[:1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !over.Rollup.Overcommitted() {
+		t.Errorf("expected overcommitted bounty, remaining %s", over.Rollup.Remaining)
+	}
+}
+
+func TestGetBountyTreePages(t *testing.T) {
+	total := childBountyPageSize + 20
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/Bounty/10":
+			json.NewEncoder(w).Encode(Bounty{BountyID: 10, Value: "100000"})
+		case "/Bounty/10/child-bounties":
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			start, end := pageBounds(total, page, limit)
+			var children []Bounty
+			for id := start + 1; id <= end; id++ {
+				children = append(children, Bounty{BountyID: id, Value: "1", Status: BountyStatusActive})
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"items": children})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(Config{BaseURL: server.URL, Network: "polkadot"})
+	tree, err := c.GetBountyTree(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tree.Children) != total || tree.Rollup.Allocated != fmt.Sprint(total) {
+		t.Errorf("expected %d child bounties, got %d (allocated %s)", total, len(tree.Children), tree.Rollup.Allocated)
+	}
+}
@@ -0,0 +1,261 @@
+package polkassembly
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Call is a runtime call in structured form. Args holds one of the *CallArgs
+// types below.
+type Call struct {
+	Pallet string      `json:"pallet"`
+	Method string      `json:"method"`
+	Args   interface{} `json:"args"`
+}
+
+func (c Call) String() string {
+	return c.Pallet + "." + c.Method
+}
+
+// BatchCallArgs are the arguments of utility.batchAll
+type BatchCallArgs struct {
+	Calls []Call `json:"calls"`
+}
+
+// VoteCallArgs are the arguments of convictionVoting.vote
+type VoteCallArgs struct {
+	PollIndex int         `json:"pollIndex"`
+	Vote      AccountVote `json:"vote"`
+}
+
+// DelegateCallArgs are the arguments of convictionVoting.delegate
+type DelegateCallArgs struct {
+	Class      int        `json:"class"`
+	To         string     `json:"to"`
+	Conviction Conviction `json:"conviction"`
+	Balance    string     `json:"balance"`
+}
+
+// UndelegateCallArgs are the arguments of convictionVoting.undelegate
+type UndelegateCallArgs struct {
+	Class int `json:"class"`
+}
+
+// RemoveVoteCallArgs are the arguments of convictionVoting.removeVote. Class
+// may be nil while the referendum is ongoing.
+type RemoveVoteCallArgs struct {
+	Class *int `json:"class,omitempty"`
+	Index int  `json:"index"`
+}
+
+// PlaceDecisionDepositCallArgs are the arguments of referenda.placeDecisionDeposit
+type PlaceDecisionDepositCallArgs struct {
+	Index int `json:"index"`
+}
+
+// AccountVote mirrors the runtime AccountVote enum; exactly one field is set
+type AccountVote struct {
+	Standard     *StandardVote     `json:"standard,omitempty"`
+	Split        *SplitVote        `json:"split,omitempty"`
+	SplitAbstain *SplitAbstainVote `json:"splitAbstain,omitempty"`
+}
+
+type StandardVote struct {
+	Aye        bool       `json:"aye"`
+	Conviction Conviction `json:"conviction"`
+	Balance    string     `json:"balance"`
+}
+
+type SplitVote struct {
+	Aye string `json:"aye"`
+	Nay string `json:"nay"`
+}
+
+type SplitAbstainVote struct {
+	Aye     string `json:"aye"`
+	Nay     string `json:"nay"`
+	Abstain string `json:"abstain"`
+}
+
+// BatchAll wraps calls in utility.batchAll
+func BatchAll(calls ...Call) Call {
+	return Call{Pallet: "utility", Method: "batchAll", Args: BatchCallArgs{Calls: calls}}
+}
+
+// VoteCall builds a convictionVoting.vote call. Abstain votes are cast as
+// split-abstain, and split votes carry no conviction.
+func VoteCall(pollIndex int, decision string, conviction Conviction, amount CartAmount) (Call, error) {
+	if !conviction.Valid() {
+		return Call{}, fmt.Errorf("invalid conviction: %d", conviction)
+	}
+
+	args := VoteCallArgs{PollIndex: pollIndex}
+	switch decision {
+	case DecisionAye:
+		args.Vote.Standard = &StandardVote{Aye: true, Conviction: conviction, Balance: normalizeBalance(amount.Aye)}
+	case DecisionNay:
+		args.Vote.Standard = &StandardVote{Aye: false, Conviction: conviction, Balance: normalizeBalance(amount.Nay)}
+	case DecisionSplit:
+		args.Vote.Split = &SplitVote{Aye: normalizeBalance(amount.Aye), Nay: normalizeBalance(amount.Nay)}
+	case DecisionAbstain, DecisionSplitAbstain:
+		args.Vote.SplitAbstain = &SplitAbstainVote{
+			Aye:     normalizeBalance(amount.Aye),
+			Nay:     normalizeBalance(amount.Nay),
+			Abstain: normalizeBalance(amount.Abstain),
+		}
+	default:
+		return Call{}, fmt.Errorf("unknown vote decision: %q", decision)
+	}
+
+	return Call{Pallet: "convictionVoting", Method: "vote", Args: args}, nil
+}
+
+// DelegateCall builds a convictionVoting.delegate call delegating balance on track to the address to
+func DelegateCall(track int, to string, conviction Conviction, balance string) (Call, error) {
+	if _, err := AccountID(to); err != nil {
+		return Call{}, err
+	}
+	if !conviction.Valid() {
+		return Call{}, fmt.Errorf("invalid conviction: %d", conviction)
+	}
+	b, err := ParseBalance(balance)
+	if err != nil {
+		return Call{}, err
+	}
+	if b.Sign() <= 0 {
+		return Call{}, fmt.Errorf("delegated balance must be positive")
+	}
+
+	return Call{Pallet: "convictionVoting", Method: "delegate", Args: DelegateCallArgs{
+		Class:      track,
+		To:         to,
+		Conviction: conviction,
+		Balance:    b.String(),
+	}}, nil
+}
+
+// UndelegateCall builds a convictionVoting.undelegate call for track
+func UndelegateCall(track int) Call {
+	return Call{Pallet: "convictionVoting", Method: "undelegate", Args: UndelegateCallArgs{Class: track}}
+}
+
+// RemoveVoteCall builds a convictionVoting.removeVote call. The track is
+// required once the referendum has ended.
+func RemoveVoteCall(track *int, index int) Call {
+	return Call{Pallet: "convictionVoting", Method: "removeVote", Args: RemoveVoteCallArgs{Class: track, Index: index}}
+}
+
+// PlaceDecisionDepositCall builds a referenda.placeDecisionDeposit call
+func PlaceDecisionDepositCall(index int) Call {
+	return Call{Pallet: "referenda", Method: "placeDecisionDeposit", Args: PlaceDecisionDepositCallArgs{Index: index}}
+}
+
+// VoteCall builds the convictionVoting.vote call of the request
+func (r CreateVoteRequest) VoteCall() (Call, error) {
+	var amount CartAmount
+	switch r.Vote {
+	case DecisionNay:
+		amount.Nay = r.Balance
+	case DecisionAbstain:
+		amount.Abstain = r.Balance
+	default:
+		amount.Aye = r.Balance
+	}
+	return VoteCall(r.PostID, r.Vote, Conviction(r.LockPeriod), amount)
+}
+
+// DelegateCall builds a convictionVoting.delegate call delegating to d on track
+func (d Delegate) DelegateCall(track int, conviction Conviction, balance string) (Call, error) {
+	return DelegateCall(track, d.Address, conviction, balance)
+}
+
+// VoteCall builds the convictionVoting.vote call of the cart item
+func (i CartItem) VoteCall() (Call, error) {
+	index, err := i.PostIndex()
+	if err != nil {
+		return Call{}, err
+	}
+	return VoteCall(index, i.Decision, Conviction(i.Conviction), i.Amount)
+}
+
+// PostIndex parses PostIndexOrHash as a referendum index
+func (i CartItem) PostIndex() (int, error) {
+	index, err := strconv.Atoi(strings.TrimSpace(i.PostIndexOrHash))
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("invalid referendum index: %q", i.PostIndexOrHash)
+	}
+	return index, nil
+}
+
+// normalizeBalance returns a balance in decimal, leaving unparsable values as-is
+func normalizeBalance(s string) string {
+	v, err := ParseBalance(s)
+	if err != nil {
+		return s
+	}
+	return v.String()
+}
+
+// value converts the vote into an encoder value
+func (v AccountVote) value() (EnumValue, error) {
+	switch {
+	case v.Standard != nil:
+		vote := uint8(v.Standard.Conviction)
+		if v.Standard.Aye {
+			vote |= 0x80
+		}
+		return EnumValue{Variant: "Standard", Value: map[string]interface{}{
+			"vote":    vote,
+			"balance": v.Standard.Balance,
+		}}, nil
+	case v.Split != nil:
+		return EnumValue{Variant: "Split", Value: map[string]interface{}{
+			"aye": v.Split.Aye,
+			"nay": v.Split.Nay,
+		}}, nil
+	case v.SplitAbstain != nil:
+		return EnumValue{Variant: "SplitAbstain", Value: map[string]interface{}{
+			"aye":     v.SplitAbstain.Aye,
+			"nay":     v.SplitAbstain.Nay,
+			"abstain": v.SplitAbstain.Abstain,
+		}}, nil
+	}
+	return EnumValue{}, fmt.Errorf("empty account vote")
+}
+
+// callArgValues converts call arguments into encoder values keyed by argument name
+func callArgValues(args interface{}) (map[string]interface{}, error) {
+	switch a := args.(type) {
+	case nil:
+		return map[string]interface{}{}, nil
+	case map[string]interface{}:
+		return a, nil
+	case BatchCallArgs:
+		return map[string]interface{}{"calls": a.Calls}, nil
+	case VoteCallArgs:
+		vote, err := a.Vote.value()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"poll_index": a.PollIndex, "vote": vote}, nil
+	case DelegateCallArgs:
+		return map[string]interface{}{
+			"class":      a.Class,
+			"to":         EnumValue{Variant: "Id", Value: a.To},
+			"conviction": a.Conviction,
+			"balance":    a.Balance,
+		}, nil
+	case UndelegateCallArgs:
+		return map[string]interface{}{"class": a.Class}, nil
+	case RemoveVoteCallArgs:
+		values := map[string]interface{}{"class": nil, "index": a.Index}
+		if a.Class != nil {
+			values["class"] = *a.Class
+		}
+		return values, nil
+	case PlaceDecisionDepositCallArgs:
+		return map[string]interface{}{"index": a.Index}, nil
+	}
+	return nil, fmt.Errorf("unsupported call arguments: %T", args)
+}
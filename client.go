@@ -1,6 +1,7 @@
 package polkassembly
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -26,6 +27,7 @@ type Client struct {
 	tokenStorage TokenStorage
 	debug        bool
 	logger       *log.Logger
+	metadata     *Metadata
 }
 
 type Config struct {
@@ -36,6 +38,8 @@ type Config struct {
 	TokenStorage TokenStorage
 	Debug        bool
 	Logger       *log.Logger
+	// Metadata overrides the built-in call metadata, e.g. from LoadMetadata
+	Metadata *Metadata
 }
 
 func NewClient(cfg Config) *Client {
@@ -74,6 +78,7 @@ func NewClient(cfg Config) *Client {
 		tokenStorage: cfg.TokenStorage,
 		debug:        cfg.Debug,
 		logger:       cfg.Logger,
+		metadata:     cfg.Metadata,
 	}
 
 	if cfg.Token != "" {
@@ -141,6 +146,39 @@ func (c *Client) parseResponse(resp *resty.Response, v interface{}) error {
 	return nil
 }
 
+// parseListResponse parses a listing returned either as a bare array or as
+// an object holding the array under "items" or key
+func (c *Client) parseListResponse(r *resty.Response, key string, v interface{}) error {
+	var raw json.RawMessage
+	if err := c.parseResponse(r, &raw); err != nil {
+		return err
+	}
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil
+	}
+	if raw[0] == '[' {
+		if err := json.Unmarshal(raw, v); err != nil {
+			return fmt.Errorf("unmarshal %s: %w", key, err)
+		}
+		return nil
+	}
+
+	var wrapped map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &wrapped); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	for _, k := range []string{"items", key} {
+		if list, ok := wrapped[k]; ok {
+			if err := json.Unmarshal(list, v); err != nil {
+				return fmt.Errorf("unmarshal %s: %w", key, err)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("response has no %s", key)
+}
+
 func (c *Client) handleAuthResponse(token string) {
 	if token != "" {
 		c.SetAuthToken(token)
@@ -0,0 +1,118 @@
+// Command generate_metadata writes the JSON metadata used by LoadMetadata from
+// the runtime metadata of a node, e.g. after a runtime upgrade:
+//
+//	go run ./cmd/generate_metadata -rpc https://rpc.polkadot.io -out polkadot.json
+//
+// The runtime metadata can also be read from a file holding the hex returned
+// by state_getMetadata, or the raw bytes, with -in.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/polkadot-go/polkassembly-api/scale"
+)
+
+func main() {
+	rpc := flag.String("rpc", "", "HTTP JSON-RPC endpoint of a node")
+	in := flag.String("in", "", "file with the runtime metadata as hex or raw bytes")
+	out := flag.String("out", "", "output file (default stdout)")
+	pallets := flag.String("pallets", "", "comma separated pallets to keep (default all)")
+	flag.Parse()
+
+	var m *scale.Metadata
+	var err error
+	switch {
+	case *rpc != "":
+		m, err = fetchMetadata(*rpc)
+	case *in != "":
+		m, err = readMetadata(*in)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *pallets != "" {
+		if m, err = keepPallets(m, strings.Split(*pallets, ",")); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Types such as Compact<u32> stay readable without HTML escaping
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(m); err != nil {
+		log.Fatal(err)
+	}
+	if *out == "" {
+		os.Stdout.Write(buf.Bytes())
+		return
+	}
+	if err := os.WriteFile(*out, buf.Bytes(), 0o644); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %d pallets of spec version %d to %s\n", len(m.Pallets), m.SpecVersion, *out)
+}
+
+// fetchMetadata calls state_getMetadata on a node
+func fetchMetadata(url string) (*scale.Metadata, error) {
+	body := []byte(`{"jsonrpc":"2.0","id":1,"method":"state_getMetadata","params":[]}`)
+	resp, err := http.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("fetch metadata: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Result string `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("fetch metadata: HTTP %d: %w", resp.StatusCode, err)
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("fetch metadata: %s", result.Error.Message)
+	}
+	return scale.ParseRuntimeMetadataHex(result.Result)
+}
+
+// readMetadata reads runtime metadata saved as hex or raw bytes
+func readMetadata(path string) (*scale.Metadata, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if s := strings.TrimSpace(string(data)); strings.HasPrefix(s, "0x") {
+		return scale.ParseRuntimeMetadataHex(s)
+	}
+	return scale.ParseRuntimeMetadata(data)
+}
+
+// keepPallets drops the pallets not listed. Types are kept, since they may
+// be shared between pallets.
+func keepPallets(m *scale.Metadata, names []string) (*scale.Metadata, error) {
+	kept := *m
+	kept.Pallets = nil
+	for _, name := range names {
+		p, err := m.Pallet(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		kept.Pallets = append(kept.Pallets, *p)
+	}
+	return &kept, nil
+}
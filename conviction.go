@@ -0,0 +1,204 @@
+package polkassembly
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// Conviction is an OpenGov vote conviction from 0 (0.1x, no lock) to 6 (6x)
+type Conviction int
+
+const (
+	ConvictionNone Conviction = iota
+	ConvictionLocked1x
+	ConvictionLocked2x
+	ConvictionLocked3x
+	ConvictionLocked4x
+	ConvictionLocked5x
+	ConvictionLocked6x
+)
+
+// Vote decisions
+const (
+	DecisionAye          = "aye"
+	DecisionNay          = "nay"
+	DecisionAbstain      = "abstain"
+	DecisionSplit        = "split"
+	DecisionSplitAbstain = "splitAbstain"
+)
+
+// voteLockingPeriods is the runtime VoteLockingPeriod in blocks
+var voteLockingPeriods = map[string]int{
+	"polkadot": 28 * BlocksPerDay,
+	"kusama":   7 * BlocksPerDay,
+}
+
+// VoteLockingPeriod returns the base lock period of network in blocks,
+// defaulting to polkadot's for unknown networks
+func VoteLockingPeriod(network string) int {
+	if period, ok := voteLockingPeriods[network]; ok {
+		return period
+	}
+	return voteLockingPeriods["polkadot"]
+}
+
+func (c Conviction) Valid() bool {
+	return c >= ConvictionNone && c <= ConvictionLocked6x
+}
+
+// Multiplier returns the vote multiplier: 0.1 for no conviction, otherwise 1-6
+func (c Conviction) Multiplier() float64 {
+	return float64(c.tenths()) / 10
+}
+
+// tenths returns the multiplier in tenths so it can be applied exactly to balances
+func (c Conviction) tenths() int64 {
+	if c <= ConvictionNone {
+		return 1
+	}
+	if c > ConvictionLocked6x {
+		c = ConvictionLocked6x
+	}
+	return int64(c) * 10
+}
+
+// LockPeriods is the number of base lock periods: 0, 1, 2, 4, 8, 16 or 32
+func (c Conviction) LockPeriods() int {
+	if c <= ConvictionNone {
+		return 0
+	}
+	return 1 << (c - 1)
+}
+
+// LockBlocks returns how long funds stay locked on network after the referendum ends
+func (c Conviction) LockBlocks(network string) int {
+	return c.LockPeriods() * VoteLockingPeriod(network)
+}
+
+// LockDuration returns LockBlocks as wall-clock time
+func (c Conviction) LockDuration(network string) time.Duration {
+	return BlocksToDuration(c.LockBlocks(network))
+}
+
+func (c Conviction) String() string {
+	if c <= ConvictionNone {
+		return "0.1x"
+	}
+	return fmt.Sprintf("%dx", c)
+}
+
+// EffectiveVotes applies conviction to a balance
+func EffectiveVotes(balance *big.Int, conviction Conviction) *big.Int {
+	v := new(big.Int).Mul(balance, big.NewInt(conviction.tenths()))
+	return v.Quo(v, big.NewInt(10))
+}
+
+// VoteWeight is the contribution of a vote to a referendum tally. Aye and Nay
+// are conviction-weighted; Abstain and Support are capital, which is how the
+// runtime counts them.
+type VoteWeight struct {
+	Aye     *big.Int
+	Nay     *big.Int
+	Abstain *big.Int
+	Support *big.Int
+}
+
+// ComputeVoteWeight computes the tally contribution of a vote. Split and
+// split-abstain votes always count with no conviction.
+func ComputeVoteWeight(decision string, conviction Conviction, amount CartAmount) (*VoteWeight, error) {
+	aye, err := ParseBalance(amount.Aye)
+	if err != nil {
+		return nil, err
+	}
+	nay, err := ParseBalance(amount.Nay)
+	if err != nil {
+		return nil, err
+	}
+	abstain, err := ParseBalance(amount.Abstain)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &VoteWeight{
+		Aye:     new(big.Int),
+		Nay:     new(big.Int),
+		Abstain: new(big.Int),
+		Support: new(big.Int),
+	}
+
+	switch decision {
+	case DecisionAye:
+		w.Aye = EffectiveVotes(aye, conviction)
+		w.Support.Set(aye)
+	case DecisionNay:
+		w.Nay = EffectiveVotes(nay, conviction)
+	case DecisionSplit:
+		w.Aye = EffectiveVotes(aye, ConvictionNone)
+		w.Nay = EffectiveVotes(nay, ConvictionNone)
+		w.Support.Set(aye)
+	case DecisionAbstain, DecisionSplitAbstain:
+		w.Aye = EffectiveVotes(aye, ConvictionNone)
+		w.Nay = EffectiveVotes(nay, ConvictionNone)
+		w.Abstain.Set(abstain)
+		w.Support.Add(aye, abstain)
+	default:
+		return nil, fmt.Errorf("unknown vote decision: %q", decision)
+	}
+
+	return w, nil
+}
+
+// Conviction returns the conviction of the vote
+func (v Vote) Conviction() Conviction {
+	return Conviction(v.LockPeriod)
+}
+
+// Amounts returns the balances of the vote per direction
+func (v Vote) Amounts() CartAmount {
+	switch v.decision() {
+	case DecisionAye:
+		return CartAmount{Aye: v.Balance}
+	case DecisionNay:
+		return CartAmount{Nay: v.Balance}
+	case DecisionAbstain:
+		if v.AbstainBalance == "" {
+			return CartAmount{Abstain: v.Balance}
+		}
+	}
+	return CartAmount{Aye: v.AyeBalance, Nay: v.NayBalance, Abstain: v.AbstainBalance}
+}
+
+// Weight computes the tally contribution of the vote
+func (v Vote) Weight() (*VoteWeight, error) {
+	return ComputeVoteWeight(v.decision(), v.Conviction(), v.Amounts())
+}
+
+func (v Vote) decision() string {
+	if v.Decision != "" {
+		return v.Decision
+	}
+	return v.Vote
+}
+
+// Weight computes the tally contribution of the cart item if it were submitted
+func (i CartItem) Weight() (*VoteWeight, error) {
+	return ComputeVoteWeight(i.Decision, Conviction(i.Conviction), i.Amount)
+}
+
+// applyConviction fills the derived conviction fields of votes
+func (c *Client) applyConviction(votes []Vote) {
+	for i := range votes {
+		v := &votes[i]
+		v.LockDuration = v.Conviction().LockDuration(c.network)
+
+		w, err := v.Weight()
+		if err != nil {
+			c.logDebug("Skipping vote weight for %s: %v", v.Voter, err)
+			continue
+		}
+		v.EffectiveAye = w.Aye.String()
+		v.EffectiveNay = w.Nay.String()
+		v.EffectiveAbstain = w.Abstain.String()
+	}
+}
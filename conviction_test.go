@@ -0,0 +1,44 @@
+package polkassembly
+
+import (
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestConviction(t *testing.T) {
+	if ConvictionNone.Multiplier() != 0.1 || ConvictionLocked6x.Multiplier() != 6 {
+		t.Errorf("unexpected multipliers: %v %v", ConvictionNone.Multiplier(), ConvictionLocked6x.Multiplier())
+	}
+	if ConvictionLocked6x.LockDuration("polkadot") != 32*28*24*time.Hour {
+		t.Errorf("unexpected 6x lock: %s", ConvictionLocked6x.LockDuration("polkadot"))
+	}
+	if ConvictionLocked1x.LockDuration("kusama") != 7*24*time.Hour {
+		t.Errorf("unexpected kusama 1x lock: %s", ConvictionLocked1x.LockDuration("kusama"))
+	}
+
+	if got := EffectiveVotes(big.NewInt(1000), ConvictionNone); got.Int64() != 100 {
+		t.Errorf("expected 100, got %s", got)
+	}
+
+	votes := []Vote{
+		{Voter: "a", Decision: DecisionAye, Balance: "1000", LockPeriod: 3},
+		{Voter: "b", Decision: DecisionSplitAbstain, AyeBalance: "100", NayBalance: "200", AbstainBalance: "300", LockPeriod: 6},
+	}
+	testClient.applyConviction(votes)
+
+	if votes[0].EffectiveAye != "3000" || votes[0].LockDuration == 0 {
+		t.Errorf("unexpected aye vote: %+v", votes[0])
+	}
+	if votes[1].EffectiveAye != "10" || votes[1].EffectiveNay != "20" || votes[1].EffectiveAbstain != "300" {
+		t.Errorf("unexpected split abstain vote: %+v", votes[1])
+	}
+
+	w, err := votes[1].Weight()
+	if err != nil {
+		t.Fatalf("Weight failed: %v", err)
+	}
+	if w.Support.Int64() != 400 {
+		t.Errorf("expected support 400, got %s", w.Support)
+	}
+}
@@ -0,0 +1,245 @@
+package polkassembly
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+)
+
+// Threshold evaluates the curve at x, the elapsed fraction of the decision
+// period, returning the required fraction in [0, 1]
+func (c Curve) Threshold(x float64) float64 {
+	x = clamp(x, 0, 1)
+
+	switch c.Type {
+	case CurveLinearDecreasing:
+		if c.Length <= 0 {
+			return c.Floor
+		}
+		return c.Ceil - math.Min(x, c.Length)/c.Length*(c.Ceil-c.Floor)
+	case CurveSteppedDecreasing:
+		if c.Period <= 0 {
+			return c.Begin
+		}
+		steps := math.Floor(x / c.Period)
+		return math.Max(c.End, c.Begin-math.Min(c.Step*steps, c.Begin))
+	case CurveReciprocal:
+		factor, xOffset, yOffset := c.reciprocalParts()
+		return clamp(factor/(x+xOffset)+yOffset, 0, 1)
+	default:
+		return 1
+	}
+}
+
+// DelayFor is the inverse of Threshold: the earliest fraction of the decision
+// period at which the curve is at or below y. It returns +Inf if the curve
+// never falls to y.
+func (c Curve) DelayFor(y float64) float64 {
+	switch c.Type {
+	case CurveLinearDecreasing:
+		switch {
+		case y < c.Floor:
+			return math.Inf(1)
+		case y >= c.Ceil:
+			return 0
+		default:
+			return (c.Ceil - y) / (c.Ceil - c.Floor) * c.Length
+		}
+	case CurveSteppedDecreasing:
+		if y < c.End {
+			return math.Inf(1)
+		}
+		if y >= c.Begin || c.Step <= 0 {
+			return 0
+		}
+		return c.Period * math.Ceil((c.Begin-y)/c.Step)
+	case CurveReciprocal:
+		factor, xOffset, yOffset := c.reciprocalParts()
+		if y >= c.Threshold(0) {
+			return 0
+		}
+		if y-yOffset <= 0 || y < c.Threshold(1) {
+			return math.Inf(1)
+		}
+		return math.Max(0, factor/(y-yOffset)-xOffset)
+	default:
+		return math.Inf(1)
+	}
+}
+
+// reciprocalParts finds the factor, x offset and y offset of a reciprocal
+// curve the same way the runtime's make_reciprocal does: bisect on factor
+// until the curve passes through Level at Delay
+func (c Curve) reciprocalParts() (factor, xOffset, yOffset float64) {
+	// x offset chosen so the curve spans exactly Ceil at 0 to Floor at 1
+	fromParts := func(factor float64) (float64, float64) {
+		xOffset := (math.Sqrt(1+4*factor/(c.Ceil-c.Floor)) - 1) / 2
+		yOffset := c.Floor - factor/(1+xOffset)
+		return xOffset, yOffset
+	}
+	level := func(factor float64) float64 {
+		xOffset, yOffset := fromParts(factor)
+		return clamp(factor/(c.Delay+xOffset)+yOffset, 0, 1)
+	}
+
+	lo, hi := 0.0, 1.0
+	for i := 0; i < 64; i++ {
+		mid := (lo + hi) / 2
+		if level(mid) > c.Level {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+
+	factor = (lo + hi) / 2
+	xOffset, yOffset = fromParts(factor)
+	return factor, xOffset, yOffset
+}
+
+// ApprovalThreshold is the approval required at x, the elapsed fraction of the decision period
+func (t Track) ApprovalThreshold(x float64) float64 {
+	return t.MinApproval.Threshold(x)
+}
+
+// SupportThreshold is the support required at x, the elapsed fraction of the decision period
+func (t Track) SupportThreshold(x float64) float64 {
+	return t.MinSupport.Threshold(x)
+}
+
+// ReferendumTally reports the standing of a referendum against its track's
+// curves. Percentages are in [0, 100].
+type ReferendumTally struct {
+	Track            Track         `json:"track"`
+	Elapsed          time.Duration `json:"elapsed"`
+	ElapsedFraction  float64       `json:"elapsedFraction"`
+	ApprovalPercent  float64       `json:"approvalPercent"`
+	SupportPercent   float64       `json:"supportPercent"`
+	RequiredApproval float64       `json:"requiredApproval"`
+	RequiredSupport  float64       `json:"requiredSupport"`
+	PassingApproval  bool          `json:"passingApproval"`
+	PassingSupport   bool          `json:"passingSupport"`
+	Passing          bool          `json:"passing"`
+	// WouldPass reports whether the current tally meets both curves before the decision period ends
+	WouldPass bool `json:"wouldPass"`
+	// PassesAfter is the decision-period offset at which the current tally meets both curves
+	PassesAfter time.Duration `json:"passesAfter,omitempty"`
+	// ConfirmedAfter is PassesAfter (or Elapsed, if already passing) plus the confirm period
+	ConfirmedAfter time.Duration `json:"confirmedAfter,omitempty"`
+}
+
+// EvaluateTally computes the tally of post against its track's approval and
+// support curves. elapsed is the time spent in the decision period and
+// totalIssuance is the electorate used to compute support.
+func EvaluateTally(post *Post, network string, elapsed time.Duration, totalIssuance string) (*ReferendumTally, error) {
+	if post.OnChainInfo == nil {
+		return nil, fmt.Errorf("onchain data not available for post %d", post.Index)
+	}
+
+	track, err := postTrack(post, network)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := post.OnChainInfo.VoteMetrics
+	aye, err := ParseBalance(metrics.Aye.Value)
+	if err != nil {
+		return nil, err
+	}
+	nay, err := ParseBalance(metrics.Nay.Value)
+	if err != nil {
+		return nil, err
+	}
+	support, err := ParseBalance(metrics.Support.Value)
+	if err != nil {
+		return nil, err
+	}
+	issuance, err := ParseBalance(totalIssuance)
+	if err != nil {
+		return nil, err
+	}
+	if issuance.Sign() == 0 {
+		return nil, fmt.Errorf("total issuance is required to compute support")
+	}
+
+	approval := ratio(aye, new(big.Int).Add(aye, nay))
+	supportFraction := ratio(support, issuance)
+
+	return tallyAt(*track, elapsed, approval, supportFraction), nil
+}
+
+func tallyAt(track Track, elapsed time.Duration, approval, support float64) *ReferendumTally {
+	decision := track.DecisionDuration()
+	x := clamp(float64(elapsed)/float64(decision), 0, 1)
+
+	tally := &ReferendumTally{
+		Track:            track,
+		Elapsed:          elapsed,
+		ElapsedFraction:  x,
+		ApprovalPercent:  approval * 100,
+		SupportPercent:   support * 100,
+		RequiredApproval: track.ApprovalThreshold(x) * 100,
+		RequiredSupport:  track.SupportThreshold(x) * 100,
+	}
+	tally.PassingApproval = approval >= track.ApprovalThreshold(x)
+	tally.PassingSupport = support >= track.SupportThreshold(x)
+	tally.Passing = tally.PassingApproval && tally.PassingSupport
+
+	passX := math.Max(track.MinApproval.DelayFor(approval), track.MinSupport.DelayFor(support))
+	if passX <= 1 {
+		tally.WouldPass = true
+		tally.PassesAfter = time.Duration(passX * float64(decision))
+		start := tally.PassesAfter
+		if tally.Passing {
+			start = elapsed
+		}
+		tally.ConfirmedAfter = start + track.ConfirmDuration()
+	}
+
+	return tally
+}
+
+// GetReferendumTally fetches a referendum and evaluates it against its track's
+// curves at the current time
+func (c *Client) GetReferendumTally(postID int, totalIssuance string) (*ReferendumTally, error) {
+	post, err := c.GetPostByType(postID, ProposalTypeReferendumV2)
+	if err != nil {
+		return nil, err
+	}
+	if post.OnChainInfo == nil {
+		return nil, fmt.Errorf("onchain data not available for post %d", postID)
+	}
+
+	track, err := postTrack(post, c.network)
+	if err != nil {
+		return nil, err
+	}
+
+	elapsed := time.Duration(0)
+	if !post.OnChainInfo.DecisionPeriodEndsAt.IsZero() {
+		started := post.OnChainInfo.DecisionPeriodEndsAt.Add(-track.DecisionDuration())
+		elapsed = time.Since(started)
+	}
+
+	return EvaluateTally(post, c.network, elapsed, totalIssuance)
+}
+
+// postTrack resolves the track of a referendum from its origin or track number.
+// A zero track number is taken as missing rather than Root, since Root
+// referendums carry their origin.
+func postTrack(post *Post, network string) (*Track, error) {
+	if post.OnChainInfo != nil && post.OnChainInfo.Origin != "" {
+		if track, err := TrackByOrigin(network, post.OnChainInfo.Origin); err == nil {
+			return track, nil
+		}
+	}
+	if post.TrackNumber == 0 {
+		return nil, fmt.Errorf("track of post %d is unknown", post.Index)
+	}
+	return TrackByID(network, post.TrackNumber)
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
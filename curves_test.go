@@ -0,0 +1,70 @@
+package polkassembly
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCurveThreshold(t *testing.T) {
+	root, err := TrackByID("polkadot", 0)
+	if err != nil {
+		t.Fatalf("TrackByID failed: %v", err)
+	}
+
+	approx := func(name string, got, want float64) {
+		if math.Abs(got-want) > 1e-6 {
+			t.Errorf("%s: expected %f, got %f", name, want, got)
+		}
+	}
+
+	approx("approval at start", root.ApprovalThreshold(0), 1)
+	approx("approval at delay", root.ApprovalThreshold(4.0/28), 0.8)
+	approx("approval at end", root.ApprovalThreshold(1), 0.5)
+	approx("support at half", root.SupportThreshold(0.5), 0.25)
+	approx("approval delay", root.MinApproval.DelayFor(0.8), 4.0/28)
+
+	stepped := SteppedCurve(100, 50, 10, 25)
+	approx("stepped", stepped.Threshold(0.6), 0.8)
+	approx("stepped delay", stepped.DelayFor(0.8), 0.5)
+
+	if !math.IsInf(root.MinApproval.DelayFor(0.4), 1) {
+		t.Error("approval below floor should never pass")
+	}
+}
+
+func TestEvaluateTally(t *testing.T) {
+	post := &Post{OnChainInfo: &OnChainInfo{Origin: "Root"}}
+	post.OnChainInfo.VoteMetrics.Aye.Value = "900"
+	post.OnChainInfo.VoteMetrics.Nay.Value = "100"
+	post.OnChainInfo.VoteMetrics.Support.Value = "0x12c" // 300
+
+	tally, err := EvaluateTally(post, "polkadot", 14*24*time.Hour, "1000")
+	if err != nil {
+		t.Fatalf("EvaluateTally failed: %v", err)
+	}
+
+	if !tally.Passing {
+		t.Errorf("expected passing tally: %+v", tally)
+	}
+	if math.Abs(tally.SupportPercent-30) > 1e-9 {
+		t.Errorf("expected 30%% support, got %f", tally.SupportPercent)
+	}
+	if tally.ConfirmedAfter != 15*24*time.Hour {
+		t.Errorf("unexpected confirmation offset: %s", tally.ConfirmedAfter)
+	}
+}
+
+func TestPostTrack(t *testing.T) {
+	if track, err := postTrack(&Post{TrackNumber: 33}, "polkadot"); err != nil || track.ID != 33 {
+		t.Errorf("expected track 33 from the track number, got %+v, %v", track, err)
+	}
+	if track, err := postTrack(&Post{OnChainInfo: &OnChainInfo{Origin: "Root"}}, "polkadot"); err != nil || track.ID != 0 {
+		t.Errorf("expected Root from the origin, got %+v, %v", track, err)
+	}
+
+	// Neither an origin nor a track number does not mean Root
+	if track, err := postTrack(&Post{Index: 7, OnChainInfo: &OnChainInfo{Origin: "Unknown"}}, "polkadot"); err == nil {
+		t.Errorf("expected an error, got %+v", track)
+	}
+}
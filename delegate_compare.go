@@ -0,0 +1,277 @@
+package polkassembly
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// DelegateComparisonWeights weighs the components of a delegate comparison
+type DelegateComparisonWeights struct {
+	Agreement     float64
+	Participation float64
+	Power         float64
+	Recency       float64
+}
+
+// DefaultDelegateComparisonWeights favours agreement with the reference record
+var DefaultDelegateComparisonWeights = DelegateComparisonWeights{
+	Agreement:     0.4,
+	Participation: 0.3,
+	Power:         0.15,
+	Recency:       0.15,
+}
+
+// DelegateScoringOptions tunes how RankDelegates scores candidates
+type DelegateScoringOptions struct {
+	// Weights defaults to DefaultDelegateComparisonWeights when zero
+	Weights DelegateComparisonWeights
+	// RecencyHalfLife is the age of the last vote at which recency scores 0.5 (default 30 days)
+	RecencyHalfLife time.Duration
+	// Now anchors recency (default time.Now())
+	Now time.Time
+}
+
+// DelegateComparisonOptions selects the referenda Client.CompareDelegates
+// collects and how the candidates are scored
+type DelegateComparisonOptions struct {
+	// Tracks restricts the comparison to these tracks. When empty, the index
+	// range below is required.
+	Tracks    []int
+	FromIndex int
+	ToIndex   int
+	// Reference is the voting record candidates are compared against
+	Reference []VoteRecord
+	// ReferenceAddress fetches Reference over the same referenda when Reference is empty
+	ReferenceAddress string
+
+	DelegateScoringOptions
+}
+
+// DelegateActivity is the data a candidate is compared on
+type DelegateActivity struct {
+	Address string
+	Records []VoteRecord
+	// Referenda is the number of referenda the records were collected over
+	Referenda int
+	// DelegatedPower is the balance delegated to the candidate on the compared tracks
+	DelegatedPower string
+}
+
+// DelegateComparison is a ranked candidate. Rates and components are in [0, 1].
+type DelegateComparison struct {
+	Address           string    `json:"address"`
+	Compared          int       `json:"compared"`
+	Agreed            int       `json:"agreed"`
+	AgreementRate     float64   `json:"agreementRate"`
+	Voted             int       `json:"voted"`
+	ParticipationRate float64   `json:"participationRate"`
+	DelegatedPower    string    `json:"delegatedPower"`
+	Power             float64   `json:"power"`
+	LastVoteAt        time.Time `json:"lastVoteAt,omitempty"`
+	Recency           float64   `json:"recency"`
+	Score             float64   `json:"score"`
+}
+
+// RankDelegates ranks candidates by agreement with the reference record,
+// participation, delegated power relative to the strongest candidate and how
+// recently they voted. Agreement only counts referenda where both the
+// reference and the candidate voted aye or nay.
+func RankDelegates(candidates []DelegateActivity, reference []VoteRecord, opts DelegateScoringOptions) ([]DelegateComparison, error) {
+	if opts.Weights == (DelegateComparisonWeights{}) {
+		opts.Weights = DefaultDelegateComparisonWeights
+	}
+	if opts.RecencyHalfLife <= 0 {
+		opts.RecencyHalfLife = 30 * 24 * time.Hour
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	referenceDecisions := directDecisions(reference, "")
+
+	powers := make([]*big.Int, len(candidates))
+	maxPower := new(big.Int)
+	for i, cand := range candidates {
+		p, err := ParseBalance(cand.DelegatedPower)
+		if err != nil {
+			return nil, fmt.Errorf("delegated power of %s: %w", cand.Address, err)
+		}
+		powers[i] = p
+		if p.Cmp(maxPower) > 0 {
+			maxPower = p
+		}
+	}
+
+	w := opts.Weights
+	total := w.Agreement + w.Participation + w.Power + w.Recency
+
+	results := make([]DelegateComparison, len(candidates))
+	for i, cand := range candidates {
+		r := DelegateComparison{Address: cand.Address, DelegatedPower: powers[i].String()}
+
+		voted := make(map[int]bool)
+		for _, rec := range cand.Records {
+			if !SameAccount(rec.Voter, cand.Address) {
+				continue
+			}
+			voted[rec.PostID] = true
+			if rec.VotedAt.After(r.LastVoteAt) {
+				r.LastVoteAt = rec.VotedAt
+			}
+		}
+		r.Voted = len(voted)
+		if cand.Referenda > 0 {
+			r.ParticipationRate = math.Min(float64(r.Voted)/float64(cand.Referenda), 1)
+		}
+
+		for postID, decision := range directDecisions(cand.Records, cand.Address) {
+			ref, ok := referenceDecisions[postID]
+			if !ok {
+				continue
+			}
+			r.Compared++
+			if ref == decision {
+				r.Agreed++
+			}
+		}
+		if r.Compared > 0 {
+			r.AgreementRate = float64(r.Agreed) / float64(r.Compared)
+		}
+
+		if maxPower.Sign() > 0 {
+			r.Power = ratio(powers[i], maxPower)
+		}
+		if !r.LastVoteAt.IsZero() {
+			age := opts.Now.Sub(r.LastVoteAt)
+			if age < 0 {
+				age = 0
+			}
+			r.Recency = math.Pow(0.5, float64(age)/float64(opts.RecencyHalfLife))
+		}
+
+		if total > 0 {
+			r.Score = (w.Agreement*r.AgreementRate +
+				w.Participation*r.ParticipationRate +
+				w.Power*r.Power +
+				w.Recency*r.Recency) / total
+		}
+		results[i] = r
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if math.Abs(results[i].Score-results[j].Score) > 1e-12 {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Address < results[j].Address
+	})
+	return results, nil
+}
+
+// directDecisions maps post IDs to the aye or nay decisions in records cast
+// by address itself, or by anyone when address is empty
+func directDecisions(records []VoteRecord, address string) map[int]string {
+	decisions := make(map[int]string)
+	for _, rec := range records {
+		if rec.Decision != DecisionAye && rec.Decision != DecisionNay {
+			continue
+		}
+		if address != "" && !SameAccount(rec.Voter, address) {
+			continue
+		}
+		decisions[rec.PostID] = rec.Decision
+	}
+	return decisions
+}
+
+// CompareDelegates fetches the vote history and delegated power of each
+// candidate over the tracks or index range in opts and ranks them with
+// RankDelegates
+func (c *Client) CompareDelegates(addresses []string, opts DelegateComparisonOptions) ([]DelegateComparison, error) {
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("no delegates to compare")
+	}
+
+	reference := opts.Reference
+	if len(reference) == 0 && opts.ReferenceAddress != "" {
+		activity, err := c.delegateActivity(opts.ReferenceAddress, opts)
+		if err != nil {
+			return nil, fmt.Errorf("reference record: %w", err)
+		}
+		reference = activity.Records
+	}
+
+	candidates := make([]DelegateActivity, 0, len(addresses))
+	for _, address := range addresses {
+		activity, err := c.delegateActivity(address, opts)
+		if err != nil {
+			return nil, fmt.Errorf("delegate %s: %w", address, err)
+		}
+
+		stats, err := c.GetUserAllTracksStats(address)
+		if err != nil {
+			return nil, fmt.Errorf("delegate %s: %w", address, err)
+		}
+		power, err := delegatedOnTracks(stats, opts.Tracks)
+		if err != nil {
+			return nil, fmt.Errorf("delegate %s: %w", address, err)
+		}
+		activity.DelegatedPower = power.String()
+
+		candidates = append(candidates, *activity)
+	}
+
+	return RankDelegates(candidates, reference, opts.DelegateScoringOptions)
+}
+
+// delegateActivity collects the votes of address on the referenda selected by opts
+func (c *Client) delegateActivity(address string, opts DelegateComparisonOptions) (*DelegateActivity, error) {
+	params := VoteHistoryParams{
+		Address:   address,
+		FromIndex: opts.FromIndex,
+		ToIndex:   opts.ToIndex,
+	}
+
+	tracks := []*int{nil}
+	if len(opts.Tracks) > 0 {
+		tracks = tracks[:0]
+		for i := range opts.Tracks {
+			tracks = append(tracks, &opts.Tracks[i])
+		}
+	}
+
+	activity := &DelegateActivity{Address: address}
+	for _, track := range tracks {
+		params.Track = track
+		history, err := c.GetVoteHistory(params)
+		if err != nil {
+			return nil, err
+		}
+		activity.Records = append(activity.Records, history.Records...)
+		activity.Referenda += history.Referenda
+	}
+	return activity, nil
+}
+
+// delegatedOnTracks sums the delegated amounts of stats on tracks, or on all tracks when empty
+func delegatedOnTracks(stats []TrackStats, tracks []int) (*big.Int, error) {
+	include := make(map[int]bool)
+	for _, t := range tracks {
+		include[t] = true
+	}
+
+	total := new(big.Int)
+	for _, s := range stats {
+		if len(include) > 0 && !include[s.TrackID] {
+			continue
+		}
+		amount, err := ParseBalance(s.DelegatedAmount)
+		if err != nil {
+			return nil, err
+		}
+		total.Add(total, amount)
+	}
+	return total, nil
+}
@@ -0,0 +1,57 @@
+package polkassembly
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRankDelegates(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	reference := []VoteRecord{
+		{PostID: 1, Voter: charlieAddress, Decision: DecisionAye},
+		{PostID: 2, Voter: charlieAddress, Decision: DecisionNay},
+		{PostID: 3, Voter: charlieAddress, Decision: DecisionAye},
+	}
+
+	candidates := []DelegateActivity{
+		{
+			Address: aliceAddress,
+			Records: []VoteRecord{
+				{PostID: 1, Voter: aliceAddress, Decision: DecisionAye, VotedAt: now},
+				{PostID: 2, Voter: aliceAddress, Decision: DecisionNay, VotedAt: now},
+				{PostID: 3, Voter: aliceAddress, Decision: DecisionAbstain, VotedAt: now},
+			},
+			Referenda:      4,
+			DelegatedPower: "100",
+		},
+		{
+			Address: bobAddress,
+			Records: []VoteRecord{
+				{PostID: 1, Voter: bobAddress, Decision: DecisionNay, VotedAt: now.Add(-30 * 24 * time.Hour)},
+				// Votes of delegators do not count as the candidate's own
+				{PostID: 2, Voter: charlieAddress, DelegatedTo: bobAddress, Decision: DecisionNay},
+			},
+			Referenda:      4,
+			DelegatedPower: "400",
+		},
+	}
+
+	ranked, err := RankDelegates(candidates, reference, DelegateScoringOptions{Now: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alice, bob := ranked[0], ranked[1]
+	if alice.Address != aliceAddress {
+		t.Fatalf("expected alice first, got %+v", ranked)
+	}
+	if alice.Compared != 2 || alice.Agreed != 2 || alice.ParticipationRate != 0.75 || alice.Recency != 1 || alice.Power != 0.25 {
+		t.Errorf("unexpected alice comparison: %+v", alice)
+	}
+	if bob.Compared != 1 || bob.Agreed != 0 || bob.Voted != 1 || bob.Power != 1 {
+		t.Errorf("unexpected bob comparison: %+v", bob)
+	}
+	if bob.Recency < 0.499 || bob.Recency > 0.501 {
+		t.Errorf("expected half recency after one half-life, got %f", bob.Recency)
+	}
+}
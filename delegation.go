@@ -1,6 +1,50 @@
 package polkassembly
 
-import "fmt"
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DelegateSource is a registry that lists delegates
+type DelegateSource string
+
+const (
+	DelegateSourcePolkassembly DelegateSource = "polkassembly"
+	DelegateSourceNova         DelegateSource = "nova"
+	DelegateSourceParity       DelegateSource = "parity"
+	DelegateSourceW3F          DelegateSource = "w3f"
+)
+
+// DelegateSources is the dataSource of a delegate, sent by the API either as
+// one source, possibly comma separated, or as a list
+type DelegateSources []DelegateSource
+
+func (s *DelegateSources) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*s = nil
+		for _, source := range strings.Split(one, ",") {
+			if source = strings.TrimSpace(source); source != "" {
+				*s = append(*s, DelegateSource(source))
+			}
+		}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]DelegateSource)(s))
+}
+
+// DelegateSort orders delegate listings
+type DelegateSort string
+
+const (
+	DelegateSortDelegatedBalance DelegateSort = "delegated_balance"
+	DelegateSortDelegations      DelegateSort = "delegations_count"
+	DelegateSortVotedProposals   DelegateSort = "voted_proposals"
+	DelegateSortScore            DelegateSort = "score"
+	DelegateSortCreatedAt        DelegateSort = "created_at"
+)
 
 func (c *Client) GetDelegationStats() (*DelegationStats, error) {
 	r, err := c.client.R().
@@ -16,13 +60,39 @@ func (c *Client) GetDelegationStats() (*DelegationStats, error) {
 }
 
 func (c *Client) GetDelegates(page, limit int) ([]Delegate, error) {
+	return c.ListDelegates(DelegateListingParams{Page: page, Limit: limit})
+}
+
+// ListDelegates lists delegates with filtering and sorting done by the server
+func (c *Client) ListDelegates(params DelegateListingParams) ([]Delegate, error) {
 	queryParams := make(map[string]string)
-	if page > 0 {
-		queryParams["page"] = fmt.Sprintf("%d", page)
+	if params.Page > 0 {
+		queryParams["page"] = fmt.Sprintf("%d", params.Page)
+	}
+	if params.Limit > 0 {
+		queryParams["limit"] = fmt.Sprintf("%d", params.Limit)
+	}
+	if len(params.Sources) > 0 {
+		sources := make([]string, len(params.Sources))
+		for i, source := range params.Sources {
+			sources[i] = string(source)
+		}
+		queryParams["sources"] = strings.Join(sources, ",")
 	}
-	if limit > 0 {
-		queryParams["limit"] = fmt.Sprintf("%d", limit)
+	if params.Track != nil {
+		queryParams["trackNo"] = fmt.Sprintf("%d", *params.Track)
 	}
+	if params.Search != "" {
+		queryParams["search"] = params.Search
+	}
+	if params.SortBy != "" {
+		queryParams["sortBy"] = string(params.SortBy)
+		queryParams["sortOrder"] = "desc"
+		if params.Ascending {
+			queryParams["sortOrder"] = "asc"
+		}
+	}
+
 	r, err := c.client.R().
 		SetQueryParams(queryParams).
 		Get("/delegation/delegates")
@@ -36,6 +106,80 @@ func (c *Client) GetDelegates(page, limit int) ([]Delegate, error) {
 	return resp, nil
 }
 
+// FilterDelegates applies the filters and sort order of params to delegates.
+// Delegates that report no sources or no track balances are kept when
+// filtering by source or track.
+func FilterDelegates(delegates []Delegate, params DelegateListingParams) []Delegate {
+	search := strings.ToLower(strings.TrimSpace(params.Search))
+
+	var filtered []Delegate
+	for _, d := range delegates {
+		if len(params.Sources) > 0 && len(d.Sources) > 0 && !d.HasSource(params.Sources...) {
+			continue
+		}
+		if params.Track != nil && len(d.TrackBalances) > 0 && d.TrackBalance(*params.Track) == nil {
+			continue
+		}
+		if search != "" &&
+			!strings.Contains(strings.ToLower(d.Name), search) &&
+			!strings.Contains(strings.ToLower(d.Address), search) {
+			continue
+		}
+		filtered = append(filtered, d)
+	}
+
+	if params.SortBy != "" {
+		sort.SliceStable(filtered, func(i, j int) bool {
+			a, b := filtered[i], filtered[j]
+			if params.Ascending {
+				a, b = b, a
+			}
+			return delegateGreater(a, b, params.SortBy)
+		})
+	}
+	return filtered
+}
+
+func delegateGreater(a, b Delegate, by DelegateSort) bool {
+	switch by {
+	case DelegateSortDelegatedBalance:
+		x, errA := ParseBalance(a.DelegatedBalance)
+		y, errB := ParseBalance(b.DelegatedBalance)
+		return errA == nil && errB == nil && x.Cmp(y) > 0
+	case DelegateSortDelegations:
+		return a.DelegationsCount > b.DelegationsCount
+	case DelegateSortVotedProposals:
+		return a.VotedProposals > b.VotedProposals
+	case DelegateSortScore:
+		return a.Score > b.Score
+	case DelegateSortCreatedAt:
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return false
+}
+
+// HasSource reports whether the delegate is listed in any of sources
+func (d Delegate) HasSource(sources ...DelegateSource) bool {
+	for _, have := range d.Sources {
+		for _, want := range sources {
+			if strings.EqualFold(string(have), string(want)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// TrackBalance returns the delegations received on track, or nil if none
+func (d Delegate) TrackBalance(track int) *DelegateTrackBalance {
+	for i := range d.TrackBalances {
+		if d.TrackBalances[i].TrackID == track {
+			return &d.TrackBalances[i]
+		}
+	}
+	return nil
+}
+
 func (c *Client) CreatePADelegate(req CreatePADelegateRequest) (*Delegate, error) {
 	r, err := c.client.R().
 		SetBody(req).
@@ -50,9 +194,9 @@ func (c *Client) CreatePADelegate(req CreatePADelegateRequest) (*Delegate, error
 	return &resp, nil
 }
 
-func (c *Client) UpdatePADelegate(address string, manifesto string) (*Delegate, error) {
+func (c *Client) UpdatePADelegate(address string, req UpdatePADelegateRequest) (*Delegate, error) {
 	r, err := c.client.R().
-		SetBody(map[string]string{"manifesto": manifesto}).
+		SetBody(req).
 		Patch(fmt.Sprintf("/delegation/delegates/%s", address))
 	if err != nil {
 		return nil, err
@@ -111,3 +255,37 @@ func (c *Client) GetUserTracksLevelData(address string, trackNum int) ([]TrackLe
 	}
 	return resp, nil
 }
+
+// GetUserTrackDelegations returns the delegations an address received and gave on a track
+func (c *Client) GetUserTrackDelegations(address string, trackNum int) (*TrackDelegations, error) {
+	r, err := c.client.R().
+		Get(fmt.Sprintf("/users/address/%s/delegation/tracks/%d/delegations", address, trackNum))
+	if err != nil {
+		return nil, err
+	}
+	var resp TrackDelegations
+	if err := c.parseResponse(r, &resp); err != nil {
+		return nil, err
+	}
+	resp.TrackID = trackNum
+	return &resp, nil
+}
+
+// GetUserDelegations returns the delegations of an address on every track
+// it has delegation activity on
+func (c *Client) GetUserDelegations(address string) ([]TrackDelegations, error) {
+	stats, err := c.GetUserAllTracksStats(address)
+	if err != nil {
+		return nil, err
+	}
+
+	var delegations []TrackDelegations
+	for _, s := range stats {
+		d, err := c.GetUserTrackDelegations(address, s.TrackID)
+		if err != nil {
+			return nil, fmt.Errorf("track %d: %w", s.TrackID, err)
+		}
+		delegations = append(delegations, *d)
+	}
+	return delegations, nil
+}
@@ -0,0 +1,362 @@
+package polkassembly
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// DelegationEdge is a delegation from one account to another on a track
+type DelegationEdge struct {
+	Delegator  string     `json:"delegator"`
+	Delegate   string     `json:"delegate"`
+	Track      int        `json:"track"`
+	Balance    string     `json:"balance"`
+	Conviction Conviction `json:"conviction"`
+}
+
+// Effective returns the conviction-weighted balance of the delegation
+func (e DelegationEdge) Effective() (*big.Int, error) {
+	balance, err := ParseBalance(e.Balance)
+	if err != nil {
+		return nil, err
+	}
+	return EffectiveVotes(balance, e.Conviction), nil
+}
+
+// DelegationGraph models delegations per track. An account delegates to at
+// most one delegate per track, so adding an edge replaces any previous one.
+type DelegationGraph struct {
+	edges map[string]DelegationEdge // keyed by delegator and track
+}
+
+func NewDelegationGraph() *DelegationGraph {
+	return &DelegationGraph{edges: make(map[string]DelegationEdge)}
+}
+
+func edgeKey(delegator string, track int) string {
+	return fmt.Sprintf("%s/%d", accountKey(delegator), track)
+}
+
+// AddEdge records a delegation
+func (g *DelegationGraph) AddEdge(e DelegationEdge) {
+	g.edges[edgeKey(e.Delegator, e.Track)] = e
+}
+
+// AddVotes records the delegations revealed by delegated votes on a referendum of track
+func (g *DelegationGraph) AddVotes(track int, votes []Vote) {
+	for _, v := range votes {
+		if !v.IsDelegated || v.DelegatedTo == "" {
+			continue
+		}
+		g.AddEdge(DelegationEdge{
+			Delegator:  v.Voter,
+			Delegate:   v.DelegatedTo,
+			Track:      track,
+			Balance:    v.Balance,
+			Conviction: v.Conviction(),
+		})
+	}
+}
+
+// AddTrackDelegations records delegations received and given on a track
+func (g *DelegationGraph) AddTrackDelegations(d TrackDelegations) {
+	for _, list := range [][]TrackDelegation{d.Received, d.Given} {
+		for _, td := range list {
+			g.AddEdge(DelegationEdge{
+				Delegator:  td.Delegator,
+				Delegate:   td.Delegate,
+				Track:      d.TrackID,
+				Balance:    td.Balance,
+				Conviction: td.Conviction,
+			})
+		}
+	}
+}
+
+// Edges returns every delegation ordered by track, delegate and delegator
+func (g *DelegationGraph) Edges() []DelegationEdge {
+	edges := make([]DelegationEdge, 0, len(g.edges))
+	for _, e := range g.edges {
+		edges = append(edges, e)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		if a.Track != b.Track {
+			return a.Track < b.Track
+		}
+		if a.Delegate != b.Delegate {
+			return a.Delegate < b.Delegate
+		}
+		return a.Delegator < b.Delegator
+	})
+	return edges
+}
+
+// DelegationOf returns the delegation of delegator on track, if any
+func (g *DelegationGraph) DelegationOf(delegator string, track int) (DelegationEdge, bool) {
+	e, ok := g.edges[edgeKey(delegator, track)]
+	return e, ok
+}
+
+// Delegators returns the delegations received by delegate on track, or on all
+// tracks when track is nil
+func (g *DelegationGraph) Delegators(delegate string, track *int) []DelegationEdge {
+	key := accountKey(delegate)
+	var edges []DelegationEdge
+	for _, e := range g.Edges() {
+		if accountKey(e.Delegate) == key && (track == nil || e.Track == *track) {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
+
+// DelegatePower is the power delegated directly to an account
+type DelegatePower struct {
+	Delegate   string `json:"delegate"`
+	Delegators int    `json:"delegators"`
+	Capital    string `json:"capital"`
+	Effective  string `json:"effective"`
+}
+
+// DelegatedPower sums the delegations received by delegate on track, or on
+// all tracks when track is nil. Delegations are not transitive on-chain, so
+// only direct delegators count.
+func (g *DelegationGraph) DelegatedPower(delegate string, track *int) (*DelegatePower, error) {
+	capital, effective := new(big.Int), new(big.Int)
+	delegators := make(map[string]bool)
+
+	for _, e := range g.Delegators(delegate, track) {
+		balance, err := ParseBalance(e.Balance)
+		if err != nil {
+			return nil, err
+		}
+		capital.Add(capital, balance)
+		effective.Add(effective, EffectiveVotes(balance, e.Conviction))
+		delegators[accountKey(e.Delegator)] = true
+	}
+
+	return &DelegatePower{
+		Delegate:   delegate,
+		Delegators: len(delegators),
+		Capital:    capital.String(),
+		Effective:  effective.String(),
+	}, nil
+}
+
+// PowerByDelegate ranks every delegate by effective delegated power
+func (g *DelegationGraph) PowerByDelegate(track *int) ([]DelegatePower, error) {
+	seen := make(map[string]bool)
+	var powers []DelegatePower
+	for _, e := range g.Edges() {
+		key := accountKey(e.Delegate)
+		if seen[key] || (track != nil && e.Track != *track) {
+			continue
+		}
+		seen[key] = true
+
+		p, err := g.DelegatedPower(e.Delegate, track)
+		if err != nil {
+			return nil, err
+		}
+		powers = append(powers, *p)
+	}
+
+	sort.Slice(powers, func(i, j int) bool {
+		a, _ := ParseBalance(powers[i].Effective)
+		b, _ := ParseBalance(powers[j].Effective)
+		return a.Cmp(b) > 0
+	})
+	return powers, nil
+}
+
+// Chain follows delegations from address on track and returns the path,
+// starting with address. A chain longer than two accounts means the first
+// delegate has itself delegated, which the runtime does not pass through.
+// Cycles stop at the first repeated account.
+func (g *DelegationGraph) Chain(address string, track int) []string {
+	chain := []string{address}
+	seen := map[string]bool{accountKey(address): true}
+
+	current := address
+	for {
+		e, ok := g.DelegationOf(current, track)
+		if !ok || seen[accountKey(e.Delegate)] {
+			return chain
+		}
+		chain = append(chain, e.Delegate)
+		seen[accountKey(e.Delegate)] = true
+		current = e.Delegate
+	}
+}
+
+// delegatePageSize is the page size used to list every delegate
+const delegatePageSize = 100
+
+// BuildDelegationGraph builds a graph from the delegations received and given
+// by every listed delegate on each track they are active on. Delegated votes
+// cast on postIDs only add delegations the endpoints did not return, e.g. to
+// delegates that are not listed.
+func (c *Client) BuildDelegationGraph(postIDs []int) (*DelegationGraph, error) {
+	g := NewDelegationGraph()
+	for page := 1; ; page++ {
+		delegates, err := c.GetDelegates(page, delegatePageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range delegates {
+			delegations, err := c.GetUserDelegations(d.Address)
+			if err != nil {
+				return nil, fmt.Errorf("delegations of %s: %w", d.Address, err)
+			}
+			for _, td := range delegations {
+				g.AddTrackDelegations(td)
+			}
+		}
+		if len(delegates) < delegatePageSize {
+			break
+		}
+	}
+
+	fromVotes := NewDelegationGraph()
+	for _, id := range postIDs {
+		post, err := c.GetPostByType(id, ProposalTypeReferendumV2)
+		if err != nil {
+			return nil, err
+		}
+
+		// Without its track the votes of a referendum cannot be placed
+		track, err := postTrack(post, c.network)
+		if err != nil {
+			c.logDebug("Skipping votes of referendum %d: %v", id, err)
+			continue
+		}
+
+		votes, err := c.GetAllVotes(id, ProposalTypeReferendumV2)
+		if err != nil {
+			return nil, err
+		}
+		fromVotes.AddVotes(track.ID, votes)
+	}
+	for _, e := range fromVotes.Edges() {
+		if _, ok := g.DelegationOf(e.Delegator, e.Track); !ok {
+			g.AddEdge(e)
+		}
+	}
+	return g, nil
+}
+
+// DelegateScoreInput is the data a delegate is scored on. Rates are in [0, 1].
+type DelegateScoreInput struct {
+	Delegate          Delegate `json:"delegate"`
+	ParticipationRate float64  `json:"participationRate"`
+	AlignmentRate     float64  `json:"alignmentRate"`
+	// MaxScore normalizes Delegate.Score; ScoreDelegates fills it from the inputs
+	MaxScore int `json:"maxScore"`
+}
+
+// DelegateScorer maps a delegate's data to a score, higher is better
+type DelegateScorer func(in DelegateScoreInput) float64
+
+// DelegateScoreWeights weighs the components of WeightedDelegateScorer
+type DelegateScoreWeights struct {
+	Participation float64
+	Alignment     float64
+	Manifesto     float64
+	Score         float64
+}
+
+// DefaultDelegateScoreWeights favours participation and alignment
+var DefaultDelegateScoreWeights = DelegateScoreWeights{
+	Participation: 0.4,
+	Alignment:     0.3,
+	Manifesto:     0.1,
+	Score:         0.2,
+}
+
+// WeightedDelegateScorer combines participation rate, alignment, manifesto
+// presence and the normalized Delegate.Score into a weighted average
+func WeightedDelegateScorer(w DelegateScoreWeights) DelegateScorer {
+	return func(in DelegateScoreInput) float64 {
+		total := w.Participation + w.Alignment + w.Manifesto + w.Score
+		if total == 0 {
+			return 0
+		}
+
+		manifesto := 0.0
+		if strings.TrimSpace(in.Delegate.Manifesto) != "" {
+			manifesto = 1
+		}
+		score := 0.0
+		if in.MaxScore > 0 {
+			score = float64(in.Delegate.Score) / float64(in.MaxScore)
+		}
+
+		return (w.Participation*in.ParticipationRate +
+			w.Alignment*in.AlignmentRate +
+			w.Manifesto*manifesto +
+			w.Score*score) / total
+	}
+}
+
+// ScoredDelegate is a delegate with its computed score
+type ScoredDelegate struct {
+	DelegateScoreInput
+	Score float64 `json:"score"`
+}
+
+// ScoreDelegates scores and ranks delegates, using the default weights when scorer is nil
+func ScoreDelegates(inputs []DelegateScoreInput, scorer DelegateScorer) []ScoredDelegate {
+	if scorer == nil {
+		scorer = WeightedDelegateScorer(DefaultDelegateScoreWeights)
+	}
+
+	maxScore := 0
+	for _, in := range inputs {
+		if in.Delegate.Score > maxScore {
+			maxScore = in.Delegate.Score
+		}
+	}
+
+	scored := make([]ScoredDelegate, len(inputs))
+	for i, in := range inputs {
+		if in.MaxScore == 0 {
+			in.MaxScore = maxScore
+		}
+		scored[i] = ScoredDelegate{DelegateScoreInput: in, Score: scorer(in)}
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		if math.Abs(scored[i].Score-scored[j].Score) > 1e-12 {
+			return scored[i].Score > scored[j].Score
+		}
+		return scored[i].Delegate.Address < scored[j].Delegate.Address
+	})
+	return scored
+}
+
+// DelegateScoreInputs gathers participation and alignment for each delegate
+// from its vote history over the referenda selected by params
+func (c *Client) DelegateScoreInputs(delegates []Delegate, params VoteHistoryParams) ([]DelegateScoreInput, error) {
+	inputs := make([]DelegateScoreInput, 0, len(delegates))
+	for _, d := range delegates {
+		p := params
+		p.Address = d.Address
+		p.IncludeDelegators = false
+
+		history, err := c.GetVoteHistory(p)
+		if err != nil {
+			return nil, err
+		}
+
+		inputs = append(inputs, DelegateScoreInput{
+			Delegate:          d,
+			ParticipationRate: history.ParticipationRate,
+			AlignmentRate:     history.AlignmentRate,
+		})
+	}
+	return inputs, nil
+}
@@ -0,0 +1,105 @@
+package polkassembly
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDelegationGraph(t *testing.T) {
+	g := NewDelegationGraph()
+	g.AddVotes(0, []Vote{
+		{Voter: aliceAddress, DelegatedTo: charlieAddress, IsDelegated: true, Balance: "100", LockPeriod: 2},
+		{Voter: bobAddress, DelegatedTo: charlieAddress, IsDelegated: true, Balance: "50", LockPeriod: 0},
+		{Voter: charlieAddress, Decision: DecisionAye, Balance: "10"},
+	})
+	g.AddEdge(DelegationEdge{Delegator: charlieAddress, Delegate: bobAddress, Track: 1, Balance: "10", Conviction: ConvictionLocked1x})
+
+	track := 0
+	power, err := g.DelegatedPower(charlieAddress, &track)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if power.Delegators != 2 || power.Capital != "150" || power.Effective != "205" {
+		t.Errorf("unexpected delegated power: %+v", power)
+	}
+
+	ranked, err := g.PowerByDelegate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ranked) != 2 || ranked[0].Delegate != charlieAddress {
+		t.Errorf("unexpected ranking: %+v", ranked)
+	}
+
+	if chain := g.Chain(aliceAddress, 0); len(chain) != 2 {
+		t.Errorf("expected alice -> charlie on track 0, got %v", chain)
+	}
+	g.AddEdge(DelegationEdge{Delegator: charlieAddress, Delegate: aliceAddress, Track: 0, Balance: "10"})
+	if chain := g.Chain(bobAddress, 0); len(chain) != 3 || chain[2] != aliceAddress {
+		t.Errorf("expected bob -> charlie -> alice and stop at the cycle, got %v", chain)
+	}
+}
+
+func TestScoreDelegates(t *testing.T) {
+	inputs := []DelegateScoreInput{
+		{Delegate: Delegate{Address: aliceAddress, Score: 50}, ParticipationRate: 1, AlignmentRate: 1},
+		{Delegate: Delegate{Address: bobAddress, Score: 100, Manifesto: "I vote"}, ParticipationRate: 0.2, AlignmentRate: 0.5},
+	}
+
+	scored := ScoreDelegates(inputs, nil)
+	if scored[0].Delegate.Address != aliceAddress {
+		t.Errorf("expected alice first with default weights, got %+v", scored)
+	}
+	// 0.4*1 + 0.3*1 + 0.2*0.5
+	if scored[0].Score < 0.799 || scored[0].Score > 0.801 {
+		t.Errorf("unexpected score %f", scored[0].Score)
+	}
+
+	scored = ScoreDelegates(inputs, WeightedDelegateScorer(DelegateScoreWeights{Manifesto: 1, Score: 1}))
+	if scored[0].Delegate.Address != bobAddress {
+		t.Errorf("expected bob first when weighing manifesto and score, got %+v", scored)
+	}
+}
+
+func TestBuildDelegationGraph(t *testing.T) {
+	routes := map[string]interface{}{
+		"/delegation/delegates":                                   []Delegate{{Address: charlieAddress}},
+		"/users/address/" + charlieAddress + "/delegation/tracks": []TrackStats{{TrackID: 0}},
+		"/users/address/" + charlieAddress + "/delegation/tracks/0/delegations": TrackDelegations{
+			Received: []TrackDelegation{{Delegator: aliceAddress, Delegate: charlieAddress, Balance: "100", Conviction: ConvictionLocked1x}},
+		},
+		"/ReferendumV2/5": Post{Index: 5, OnChainInfo: &OnChainInfo{Origin: "Root"}},
+		// The vote of alice predates the current delegation; bob delegates to an unlisted delegate
+		"/ReferendumV2/5/votes": VoteListingResponse{Votes: []Vote{
+			{Voter: aliceAddress, DelegatedTo: bobAddress, IsDelegated: true, Balance: "10"},
+			{Voter: bobAddress, DelegatedTo: aliceAddress, IsDelegated: true, Balance: "20"},
+		}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := routes[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(body)
+	}))
+	defer server.Close()
+
+	c := NewClient(Config{BaseURL: server.URL, Network: "polkadot"})
+	g, err := c.BuildDelegationGraph([]int{5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if e, ok := g.DelegationOf(aliceAddress, 0); !ok || e.Delegate != charlieAddress || e.Balance != "100" {
+		t.Errorf("expected alice to delegate 100 to charlie, got %+v", e)
+	}
+	if e, ok := g.DelegationOf(bobAddress, 0); !ok || e.Delegate != aliceAddress {
+		t.Errorf("expected bob's delegation from the vote, got %+v", e)
+	}
+	if len(g.Edges()) != 2 {
+		t.Errorf("unexpected edges: %+v", g.Edges())
+	}
+}
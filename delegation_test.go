@@ -0,0 +1,120 @@
+package polkassembly
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestFilterDelegates(t *testing.T) {
+	delegates := []Delegate{
+		{Address: aliceAddress, Name: "Alice", DelegatedBalance: "300", Sources: []DelegateSource{DelegateSourceNova}},
+		{Address: bobAddress, Name: "Bob", DelegatedBalance: "1000", Sources: []DelegateSource{DelegateSourceW3F, DelegateSourceParity},
+			TrackBalances: []DelegateTrackBalance{{TrackID: 1, DelegatedBalance: "1000"}}},
+		{Address: charlieAddress, Name: "Charlie", DelegatedBalance: "0x64", Sources: []DelegateSource{"Polkassembly"},
+			TrackBalances: []DelegateTrackBalance{{TrackID: 2, DelegatedBalance: "100"}}},
+	}
+
+	sorted := FilterDelegates(delegates, DelegateListingParams{SortBy: DelegateSortDelegatedBalance})
+	if len(sorted) != 3 || sorted[0].Name != "Bob" || sorted[2].Name != "Charlie" {
+		t.Errorf("unexpected order by balance: %+v", sorted)
+	}
+
+	asc := FilterDelegates(delegates, DelegateListingParams{SortBy: DelegateSortDelegatedBalance, Ascending: true})
+	if asc[0].Name != "Charlie" {
+		t.Errorf("expected Charlie first ascending, got %s", asc[0].Name)
+	}
+
+	bySource := FilterDelegates(delegates, DelegateListingParams{Sources: []DelegateSource{DelegateSourcePolkassembly, DelegateSourceNova}})
+	if len(bySource) != 2 {
+		t.Errorf("expected 2 delegates from polkassembly or nova, got %d", len(bySource))
+	}
+
+	// Alice reports no track balances and is kept
+	track := 1
+	if onTrack := FilterDelegates(delegates, DelegateListingParams{Track: &track}); len(onTrack) != 2 || onTrack[1].Name != "Bob" {
+		t.Errorf("unexpected track filter result: %+v", onTrack)
+	}
+
+	unsourced := append([]Delegate{{Address: aliceAddress, Name: "Dave"}}, delegates...)
+	if bySource := FilterDelegates(unsourced, DelegateListingParams{Sources: []DelegateSource{DelegateSourceW3F}}); len(bySource) != 2 {
+		t.Errorf("expected Dave and Bob, got %+v", bySource)
+	}
+
+	if found := FilterDelegates(delegates, DelegateListingParams{Search: "ali"}); len(found) != 1 || found[0].Address != aliceAddress {
+		t.Errorf("unexpected search result: %+v", found)
+	}
+}
+
+func TestListDelegates(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/delegation/delegates" {
+			http.NotFound(w, r)
+			return
+		}
+		query = r.URL.Query()
+		w.Write([]byte(`[
+			{"address": "` + aliceAddress + `", "dataSource": ["w3f", "nova"]},
+			{"address": "` + bobAddress + `", "dataSource": "parity, polkassembly"}
+		]`))
+	}))
+	defer server.Close()
+
+	c := NewClient(Config{BaseURL: server.URL, Network: "polkadot"})
+	track := 0
+	delegates, err := c.ListDelegates(DelegateListingParams{
+		Page:      2,
+		Limit:     10,
+		Sources:   []DelegateSource{DelegateSourceW3F, DelegateSourceParity},
+		Track:     &track,
+		Search:    "ali",
+		SortBy:    DelegateSortScore,
+		Ascending: true,
+	})
+	if err != nil {
+		t.Fatalf("ListDelegates failed: %v", err)
+	}
+
+	want := map[string]string{
+		"page": "2", "limit": "10", "sources": "w3f,parity", "trackNo": "0",
+		"search": "ali", "sortBy": "score", "sortOrder": "asc",
+	}
+	for key, value := range want {
+		if query.Get(key) != value {
+			t.Errorf("expected %s=%s, got %q", key, value, query.Get(key))
+		}
+	}
+
+	// Sources are read from a list as well as from a comma separated string
+	if len(delegates) != 2 || !delegates[0].HasSource(DelegateSourceNova) || len(delegates[1].Sources) != 2 || !delegates[1].HasSource(DelegateSourcePolkassembly) {
+		t.Errorf("unexpected delegate sources: %+v", delegates)
+	}
+}
+
+func TestUpdatePADelegate(t *testing.T) {
+	c, requests := newAccountTestClient(t, map[string]stubResponse{
+		"PATCH /delegation/delegates/" + aliceAddress: {Status: http.StatusOK, Body: json.RawMessage(`{"address": "` + aliceAddress + `", "manifesto": "new"}`)},
+	})
+
+	delegate, err := c.UpdatePADelegate(aliceAddress, UpdatePADelegateRequest{Manifesto: "new", Name: "Alice"})
+	if err != nil {
+		t.Fatalf("UpdatePADelegate failed: %v", err)
+	}
+	if delegate.Address != aliceAddress || delegate.Manifesto != "new" {
+		t.Errorf("unexpected delegate: %+v", delegate)
+	}
+
+	body := (*requests)[0].Body
+	if (*requests)[0].Method != http.MethodPatch || body["manifesto"] != "new" || body["name"] != "Alice" {
+		t.Errorf("unexpected update request: %+v", (*requests)[0])
+	}
+	// Fields left empty are not cleared
+	for _, key := range []string{"bio", "image"} {
+		if _, ok := body[key]; ok {
+			t.Errorf("expected an empty %s to be omitted, got %+v", key, body)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package polkassembly
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Discussion topics
+const (
+	TopicDemocracy          = 1
+	TopicCouncil            = 2
+	TopicTechnicalCommittee = 3
+	TopicTreasury           = 4
+	TopicGeneral            = 5
+	TopicRoot               = 6
+	TopicStakingAdmin       = 7
+	TopicAuctionAdmin       = 8
+	TopicFellowship         = 9
+	TopicGovernance         = 10
+	TopicWhitelist          = 11
+)
+
+// GetDiscussions lists discussions by topic and tags
+func (c *Client) GetDiscussions(params DiscussionListingParams) ([]Discussion, error) {
+	queryParams := make(map[string]string)
+	if params.Page > 0 {
+		queryParams["page"] = fmt.Sprintf("%d", params.Page)
+	}
+	if params.Limit > 0 {
+		queryParams["limit"] = fmt.Sprintf("%d", params.Limit)
+	}
+	if params.TopicID > 0 {
+		queryParams["topicId"] = fmt.Sprintf("%d", params.TopicID)
+	}
+	if len(params.Tags) > 0 {
+		queryParams["tags"] = strings.Join(params.Tags, ",")
+	}
+	if params.SortBy != "" {
+		queryParams["sortBy"] = params.SortBy
+	}
+
+	r, err := c.client.R().
+		SetQueryParams(queryParams).
+		Get(fmt.Sprintf("/%s", ProposalTypeDiscussion.PathSegment()))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp PostListingResponse
+	if err := json.Unmarshal(r.Body(), &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal discussions: %w", err)
+	}
+
+	discussions := make([]Discussion, 0, len(resp.Items))
+	for _, post := range resp.Items {
+		discussions = append(discussions, DiscussionFromPost(post))
+	}
+	return discussions, nil
+}
+
+// GetDiscussion retrieves a discussion with its last comment time taken from
+// its comments
+func (c *Client) GetDiscussion(discussionID int) (*Discussion, error) {
+	post, err := c.GetPostByType(discussionID, ProposalTypeDiscussion)
+	if err != nil {
+		return nil, err
+	}
+	d := DiscussionFromPost(*post)
+
+	comments, err := c.GetPostCommentsByType(discussionID, ProposalTypeDiscussion)
+	if err != nil {
+		c.logDebug("Could not load comments of discussion %d: %v", discussionID, err)
+		return &d, nil
+	}
+	if last := lastCommentAt(comments); last.After(d.LastCommentAt) {
+		d.LastCommentAt = last
+	}
+	if d.CommentCount == 0 {
+		d.CommentCount = countComments(comments)
+	}
+	return &d, nil
+}
+
+// CreateDiscussion creates a discussion in a topic with tags
+func (c *Client) CreateDiscussion(req CreateDiscussionRequest) (*Discussion, error) {
+	post, err := c.CreateOffchainPost(ProposalTypeDiscussion, CreateOffchainPostRequest{
+		Title:   req.Title,
+		Content: req.Content,
+		TopicID: req.TopicID,
+		Tags:    req.Tags,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d := DiscussionFromPost(*post)
+	if d.TopicID == 0 {
+		d.TopicID = req.TopicID
+	}
+	if len(d.Tags) == 0 {
+		d.Tags = req.Tags
+	}
+	return &d, nil
+}
+
+// UpdateDiscussion edits the title, content and tags of a discussion
+func (c *Client) UpdateDiscussion(discussionID int, req UpdatePostRequest) (*Discussion, error) {
+	post, err := c.UpdatePost(ProposalTypeDiscussion, discussionID, req)
+	if err != nil {
+		return nil, err
+	}
+	d := DiscussionFromPost(*post)
+	return &d, nil
+}
+
+// ConvertDiscussion links a discussion to the on-chain proposal it became.
+// The proposal post takes over the title, content and tags of the
+// discussion.
+func (c *Client) ConvertDiscussion(discussionID int, proposalType ProposalType, index int) (*Post, error) {
+	path, err := proposalPath(proposalType, ProposalTypeReferendumV2)
+	if err != nil {
+		return nil, err
+	}
+	if proposalType.IsOffChain() {
+		return nil, fmt.Errorf("cannot convert a discussion into an off-chain %s post", proposalType)
+	}
+
+	discussion, err := c.GetPostByType(discussionID, ProposalTypeDiscussion)
+	if err != nil {
+		return nil, fmt.Errorf("get discussion %d: %w", discussionID, err)
+	}
+
+	body := map[string]interface{}{
+		"title":   discussion.Title,
+		"content": discussion.Content,
+		"linkedPost": LinkedPost{
+			ProposalType: ProposalTypeDiscussion,
+			IndexOrHash:  fmt.Sprintf("%d", discussionID),
+		},
+	}
+	if len(discussion.Tags) > 0 {
+		body["tags"] = discussion.Tags
+	}
+
+	r, err := c.client.R().
+		SetBody(body).
+		Patch(fmt.Sprintf("/%s/%d", path, index))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp Post
+	if err := c.parseResponse(r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DiscussionFromPost maps a discussion post onto Discussion
+func DiscussionFromPost(p Post) Discussion {
+	d := Discussion{
+		ID:            p.Index,
+		Title:         p.Title,
+		Content:       p.Content,
+		Author:        p.Username,
+		Tags:          p.Tags,
+		TopicID:       p.TopicID,
+		ViewCount:     p.ViewsCount,
+		CommentCount:  p.CommentsCount,
+		ReactionCount: p.ReactionsCount,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+		LastCommentAt: p.LastCommentAt,
+		LinkedPost:    p.LinkedPost,
+	}
+	if d.ID == 0 {
+		d.ID = p.PostID
+	}
+	if d.Author == "" && p.PublicUser != nil {
+		d.Author = p.PublicUser.Username
+	}
+	if d.Author == "" {
+		d.Author = p.ProposerAddress
+	}
+	if d.CommentCount == 0 {
+		d.CommentCount = p.Metrics.Comments
+	}
+	if d.ReactionCount == 0 {
+		d.ReactionCount = p.Metrics.Reactions.Like + p.Metrics.Reactions.Dislike
+	}
+	return d
+}
+
+// FilterDiscussions applies the topic and tag filters of params. Discussions
+// that do not report a topic are kept when filtering by topic.
+func FilterDiscussions(discussions []Discussion, params DiscussionListingParams) []Discussion {
+	var filtered []Discussion
+	for _, d := range discussions {
+		if params.TopicID > 0 && d.TopicID != 0 && d.TopicID != params.TopicID {
+			continue
+		}
+		if !d.HasTags(params.Tags...) {
+			continue
+		}
+		filtered = append(filtered, d)
+	}
+	return filtered
+}
+
+// HasTags reports whether the discussion carries all of tags
+func (d Discussion) HasTags(tags ...string) bool {
+	return hasAllTags(d.Tags, tags)
+}
+
+// hasAllTags reports whether have contains every tag of want, ignoring case
+func hasAllTags(have, want []string) bool {
+	for _, tag := range want {
+		found := false
+		for _, t := range have {
+			if strings.EqualFold(t, tag) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// LastActivity is the latest of creation, last edit and last comment
+func (d Discussion) LastActivity() time.Time {
+	last := d.CreatedAt
+	if d.UpdatedAt.After(last) {
+		last = d.UpdatedAt
+	}
+	if d.LastCommentAt.After(last) {
+		last = d.LastCommentAt
+	}
+	return last
+}
+
+// SortDiscussionsByActivity orders discussions by most recent activity first
+func SortDiscussionsByActivity(discussions []Discussion) {
+	sort.SliceStable(discussions, func(i, j int) bool {
+		return discussions[i].LastActivity().After(discussions[j].LastActivity())
+	})
+}
+
+// lastCommentAt returns the newest comment or reply time
+func lastCommentAt(comments []Comment) time.Time {
+	var last time.Time
+	for _, comment := range comments {
+		if !comment.IsDeleted && comment.CreatedAt.After(last) {
+			last = comment.CreatedAt
+		}
+		for _, replies := range [][]Comment{comment.Replies, comment.Children} {
+			if t := lastCommentAt(replies); t.After(last) {
+				last = t
+			}
+		}
+	}
+	return last
+}
+
+func countComments(comments []Comment) int {
+	n := 0
+	for _, comment := range comments {
+		if !comment.IsDeleted {
+			n++
+		}
+		n += countComments(comment.Replies) + countComments(comment.Children)
+	}
+	return n
+}
@@ -0,0 +1,58 @@
+package polkassembly
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiscussionFromPost(t *testing.T) {
+	post := Post{Index: 7, Title: "Idea", Tags: []string{"treasury"}, TopicID: TopicTreasury,
+		PublicUser: &PublicUser{Username: "alice"}}
+	post.Metrics.Comments = 3
+	post.Metrics.Reactions.Like = 2
+
+	d := DiscussionFromPost(post)
+	if d.ID != 7 || d.Author != "alice" || d.CommentCount != 3 || d.ReactionCount != 2 || d.TopicID != TopicTreasury {
+		t.Errorf("unexpected discussion: %+v", d)
+	}
+}
+
+func TestFilterDiscussions(t *testing.T) {
+	discussions := []Discussion{
+		{ID: 1, TopicID: TopicTreasury, Tags: []string{"Funding", "tooling"}},
+		{ID: 2, TopicID: TopicGeneral, Tags: []string{"funding"}},
+		{ID: 3, Tags: []string{"funding"}},
+	}
+
+	byTopic := FilterDiscussions(discussions, DiscussionListingParams{TopicID: TopicTreasury})
+	if len(byTopic) != 2 || byTopic[0].ID != 1 || byTopic[1].ID != 3 {
+		t.Errorf("unexpected topic filter result: %+v", byTopic)
+	}
+	byTags := FilterDiscussions(discussions, DiscussionListingParams{Tags: []string{"funding", "Tooling"}})
+	if len(byTags) != 1 || byTags[0].ID != 1 {
+		t.Errorf("unexpected tag filter result: %+v", byTags)
+	}
+}
+
+func TestLastCommentAt(t *testing.T) {
+	base := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	comments := []Comment{
+		{CreatedAt: base, Replies: []Comment{{CreatedAt: base.Add(2 * time.Hour)}}},
+		{CreatedAt: base.Add(5 * time.Hour), IsDeleted: true, Children: []Comment{{CreatedAt: base.Add(time.Hour)}}},
+	}
+	if got := lastCommentAt(comments); !got.Equal(base.Add(2 * time.Hour)) {
+		t.Errorf("expected last comment at +2h, got %s", got)
+	}
+	if n := countComments(comments); n != 3 {
+		t.Errorf("expected 3 comments, got %d", n)
+	}
+
+	discussions := []Discussion{
+		{ID: 1, CreatedAt: base.Add(3 * time.Hour)},
+		{ID: 2, CreatedAt: base, LastCommentAt: base.Add(4 * time.Hour)},
+	}
+	SortDiscussionsByActivity(discussions)
+	if discussions[0].ID != 2 {
+		t.Errorf("expected most recently active discussion first, got %d", discussions[0].ID)
+	}
+}
@@ -19,7 +19,7 @@ func main() {
 		resp, err := client.GetPosts(polkassembly.PostListingParams{
 			Page:         page,
 			ListingLimit: 100,
-			ProposalType: "ReferendumV2",
+			ProposalType: polkassembly.ProposalTypeReferendumV2,
 		})
 		if err != nil {
 			log.Fatal(err)
@@ -15,7 +15,7 @@ func main() {
 
 	// Get treasury proposals
 	treasuryProps, err := client.GetPosts(polkassembly.PostListingParams{
-		ProposalType: "TreasuryProposal",
+		ProposalType: polkassembly.ProposalTypeTreasuryProposal,
 		ListingLimit: 50,
 	})
 	if err != nil {
@@ -37,7 +37,7 @@ func main() {
 
 	// Get proposals by specific track
 	trackProps, err := client.GetPosts(polkassembly.PostListingParams{
-		ProposalType: "ReferendumV2",
+		ProposalType: polkassembly.ProposalTypeReferendumV2,
 		TrackNo:      1, // Root track
 		ListingLimit: 20,
 	})
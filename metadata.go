@@ -0,0 +1,38 @@
+package polkassembly
+
+import "github.com/polkadot-go/polkassembly-api/scale"
+
+// Metadata describes the runtime calls the SCALE encoder and decoder know
+// about. See the scale package for the format.
+type Metadata = scale.Metadata
+
+type PalletMetadata = scale.PalletMetadata
+
+type CallMetadata = scale.CallMetadata
+
+type FieldMetadata = scale.FieldMetadata
+
+type VariantMetadata = scale.VariantMetadata
+
+type TypeDef = scale.TypeDef
+
+// LoadMetadata reads metadata from a JSON file
+func LoadMetadata(path string) (*Metadata, error) {
+	return scale.LoadMetadata(path)
+}
+
+// ParseMetadata parses JSON metadata
+func ParseMetadata(data []byte) (*Metadata, error) {
+	return scale.ParseMetadata(data)
+}
+
+// ParseRuntimeMetadata converts SCALE-encoded V14 or V15 runtime metadata,
+// as returned by state_getMetadata, into Metadata
+func ParseRuntimeMetadata(data []byte) (*Metadata, error) {
+	return scale.ParseRuntimeMetadata(data)
+}
+
+// ParseRuntimeMetadataHex converts 0x-prefixed hex runtime metadata
+func ParseRuntimeMetadataHex(s string) (*Metadata, error) {
+	return scale.ParseRuntimeMetadataHex(s)
+}
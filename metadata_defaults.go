@@ -0,0 +1,382 @@
+package polkassembly
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultMetadata returns built-in metadata for the governance calls on
+// polkadot and kusama, and the calls commonly found in their preimages.
+// Other networks need metadata from a file.
+func DefaultMetadata(network string) (*Metadata, error) {
+	indices, ok := defaultPalletIndices[network]
+	if !ok {
+		return nil, fmt.Errorf("no built-in metadata for network %s", network)
+	}
+
+	m := &Metadata{SS58Prefix: ss58Prefix(network), Types: governanceTypes()}
+	for name, def := range xcmTypes() {
+		m.Types[name] = def
+	}
+	for _, p := range append(governancePallets(), preimagePallets()...) {
+		index, ok := indices[p.Name]
+		if !ok {
+			continue
+		}
+		p.Index = index
+		m.Pallets = append(m.Pallets, p)
+	}
+	return m, nil
+}
+
+// defaultPalletIndices are the pallet indices of the built-in metadata
+var defaultPalletIndices = map[string]map[string]uint8{
+	"polkadot": {
+		"System":           0,
+		"Balances":         5,
+		"Treasury":         19,
+		"ConvictionVoting": 20,
+		"Referenda":        21,
+		"Whitelist":        23,
+		"Utility":          26,
+		"Bounties":         34,
+		"ChildBounties":    38,
+	},
+	"kusama": {
+		"System":           0,
+		"Balances":         4,
+		"Treasury":         18,
+		"ConvictionVoting": 20,
+		"Referenda":        21,
+		"Whitelist":        44,
+		"Utility":          24,
+		"Bounties":         35,
+		"ChildBounties":    40,
+	},
+}
+
+func governancePallets() []PalletMetadata {
+	return []PalletMetadata{
+		{Name: "ConvictionVoting", Calls: []CallMetadata{
+			{Name: "vote", Index: 0, Args: []FieldMetadata{
+				{Name: "poll_index", Type: "Compact<u32>"},
+				{Name: "vote", Type: "AccountVote"},
+			}},
+			{Name: "delegate", Index: 1, Args: []FieldMetadata{
+				{Name: "class", Type: "u16"},
+				{Name: "to", Type: "MultiAddress"},
+				{Name: "conviction", Type: "Conviction"},
+				{Name: "balance", Type: "u128"},
+			}},
+			{Name: "undelegate", Index: 2, Args: []FieldMetadata{
+				{Name: "class", Type: "u16"},
+			}},
+			{Name: "unlock", Index: 3, Args: []FieldMetadata{
+				{Name: "class", Type: "u16"},
+				{Name: "target", Type: "MultiAddress"},
+			}},
+			{Name: "remove_vote", Index: 4, Args: []FieldMetadata{
+				{Name: "class", Type: "Option<u16>"},
+				{Name: "index", Type: "u32"},
+			}},
+		}},
+		{Name: "Referenda", Calls: []CallMetadata{
+			{Name: "place_decision_deposit", Index: 1, Args: []FieldMetadata{
+				{Name: "index", Type: "u32"},
+			}},
+			{Name: "refund_decision_deposit", Index: 2, Args: []FieldMetadata{
+				{Name: "index", Type: "u32"},
+			}},
+		}},
+		{Name: "Utility", Calls: []CallMetadata{
+			{Name: "batch", Index: 0, Args: []FieldMetadata{
+				{Name: "calls", Type: "Vec<RuntimeCall>"},
+			}},
+			{Name: "batch_all", Index: 2, Args: []FieldMetadata{
+				{Name: "calls", Type: "Vec<RuntimeCall>"},
+			}},
+			{Name: "dispatch_as", Index: 3, Args: []FieldMetadata{
+				{Name: "as_origin", Type: "Box<OriginCaller>"},
+				{Name: "call", Type: "Box<RuntimeCall>"},
+			}},
+			{Name: "force_batch", Index: 4, Args: []FieldMetadata{
+				{Name: "calls", Type: "Vec<RuntimeCall>"},
+			}},
+		}},
+	}
+}
+
+func governanceTypes() map[string]TypeDef {
+	return map[string]TypeDef{
+		// Vote packs aye in the high bit and the conviction in the low bits
+		"Vote": {Alias: "u8"},
+		"AccountVote": {Variants: []VariantMetadata{
+			{Name: "Standard", Index: 0, Fields: []FieldMetadata{
+				{Name: "vote", Type: "Vote"},
+				{Name: "balance", Type: "u128"},
+			}},
+			{Name: "Split", Index: 1, Fields: []FieldMetadata{
+				{Name: "aye", Type: "u128"},
+				{Name: "nay", Type: "u128"},
+			}},
+			{Name: "SplitAbstain", Index: 2, Fields: []FieldMetadata{
+				{Name: "aye", Type: "u128"},
+				{Name: "nay", Type: "u128"},
+				{Name: "abstain", Type: "u128"},
+			}},
+		}},
+		"Conviction": {Variants: []VariantMetadata{
+			{Name: "None", Index: 0},
+			{Name: "Locked1x", Index: 1},
+			{Name: "Locked2x", Index: 2},
+			{Name: "Locked3x", Index: 3},
+			{Name: "Locked4x", Index: 4},
+			{Name: "Locked5x", Index: 5},
+			{Name: "Locked6x", Index: 6},
+		}},
+		// Only the system origin is known; extend OriginCaller in a metadata
+		// file to decode dispatchAs with other origins
+		"OriginCaller": {Variants: []VariantMetadata{
+			{Name: "system", Index: 0, Fields: []FieldMetadata{{Type: "RawOrigin"}}},
+		}},
+		"RawOrigin": {Variants: []VariantMetadata{
+			{Name: "Root", Index: 0},
+			{Name: "Signed", Index: 1, Fields: []FieldMetadata{{Type: "AccountId32"}}},
+			{Name: "None", Index: 2},
+		}},
+		"Weight": {Fields: []FieldMetadata{
+			{Name: "ref_time", Type: "Compact<u64>"},
+			{Name: "proof_size", Type: "Compact<u64>"},
+		}},
+		"MultiAddress": {Variants: []VariantMetadata{
+			{Name: "Id", Index: 0, Fields: []FieldMetadata{{Type: "AccountId32"}}},
+			{Name: "Index", Index: 1, Fields: []FieldMetadata{{Type: "Compact<u32>"}}},
+			{Name: "Raw", Index: 2, Fields: []FieldMetadata{{Type: "Bytes"}}},
+			{Name: "Address32", Index: 3, Fields: []FieldMetadata{{Type: "[u8; 32]"}}},
+			{Name: "Address20", Index: 4, Fields: []FieldMetadata{{Type: "[u8; 20]"}}},
+		}},
+	}
+}
+
+// preimagePallets describes calls commonly proposed through referenda
+func preimagePallets() []PalletMetadata {
+	return []PalletMetadata{
+		{Name: "System", Calls: []CallMetadata{
+			{Name: "remark", Index: 0, Args: []FieldMetadata{{Name: "remark", Type: "Bytes"}}},
+			{Name: "set_code", Index: 2, Args: []FieldMetadata{{Name: "code", Type: "Bytes"}}},
+			{Name: "remark_with_event", Index: 7, Args: []FieldMetadata{{Name: "remark", Type: "Bytes"}}},
+		}},
+		{Name: "Balances", Calls: []CallMetadata{
+			{Name: "transfer_allow_death", Index: 0, Args: []FieldMetadata{
+				{Name: "dest", Type: "MultiAddress"},
+				{Name: "value", Type: "Compact<u128>"},
+			}},
+			{Name: "force_transfer", Index: 2, Args: []FieldMetadata{
+				{Name: "source", Type: "MultiAddress"},
+				{Name: "dest", Type: "MultiAddress"},
+				{Name: "value", Type: "Compact<u128>"},
+			}},
+			{Name: "transfer_keep_alive", Index: 3, Args: []FieldMetadata{
+				{Name: "dest", Type: "MultiAddress"},
+				{Name: "value", Type: "Compact<u128>"},
+			}},
+		}},
+		{Name: "Treasury", Calls: []CallMetadata{
+			{Name: "spend_local", Index: 3, Args: []FieldMetadata{
+				{Name: "amount", Type: "Compact<u128>"},
+				{Name: "beneficiary", Type: "MultiAddress"},
+			}},
+			{Name: "remove_approval", Index: 4, Args: []FieldMetadata{
+				{Name: "proposal_id", Type: "Compact<u32>"},
+			}},
+			{Name: "spend", Index: 5, Args: []FieldMetadata{
+				{Name: "asset_kind", Type: "Box<VersionedLocatableAsset>"},
+				{Name: "amount", Type: "Compact<u128>"},
+				{Name: "beneficiary", Type: "Box<VersionedLocation>"},
+				{Name: "valid_from", Type: "Option<u32>"},
+			}},
+			{Name: "payout", Index: 6, Args: []FieldMetadata{{Name: "index", Type: "u32"}}},
+			{Name: "check_status", Index: 7, Args: []FieldMetadata{{Name: "index", Type: "u32"}}},
+			{Name: "void_spend", Index: 8, Args: []FieldMetadata{{Name: "index", Type: "u32"}}},
+		}},
+		{Name: "Whitelist", Calls: []CallMetadata{
+			{Name: "whitelist_call", Index: 0, Args: []FieldMetadata{{Name: "call_hash", Type: "H256"}}},
+			{Name: "remove_whitelisted_call", Index: 1, Args: []FieldMetadata{{Name: "call_hash", Type: "H256"}}},
+			{Name: "dispatch_whitelisted_call", Index: 2, Args: []FieldMetadata{
+				{Name: "call_hash", Type: "H256"},
+				{Name: "call_encoded_len", Type: "u32"},
+				{Name: "call_weight_witness", Type: "Weight"},
+			}},
+			{Name: "dispatch_whitelisted_call_with_preimage", Index: 3, Args: []FieldMetadata{
+				{Name: "call", Type: "Box<RuntimeCall>"},
+			}},
+		}},
+		{Name: "Bounties", Calls: []CallMetadata{
+			{Name: "propose_bounty", Index: 0, Args: []FieldMetadata{
+				{Name: "value", Type: "Compact<u128>"},
+				{Name: "description", Type: "Bytes"},
+			}},
+			{Name: "approve_bounty", Index: 1, Args: []FieldMetadata{{Name: "bounty_id", Type: "Compact<u32>"}}},
+			{Name: "propose_curator", Index: 2, Args: []FieldMetadata{
+				{Name: "bounty_id", Type: "Compact<u32>"},
+				{Name: "curator", Type: "MultiAddress"},
+				{Name: "fee", Type: "Compact<u128>"},
+			}},
+			{Name: "unassign_curator", Index: 3, Args: []FieldMetadata{{Name: "bounty_id", Type: "Compact<u32>"}}},
+			{Name: "award_bounty", Index: 5, Args: []FieldMetadata{
+				{Name: "bounty_id", Type: "Compact<u32>"},
+				{Name: "beneficiary", Type: "MultiAddress"},
+			}},
+			{Name: "close_bounty", Index: 7, Args: []FieldMetadata{{Name: "bounty_id", Type: "Compact<u32>"}}},
+			{Name: "extend_bounty_expiry", Index: 8, Args: []FieldMetadata{
+				{Name: "bounty_id", Type: "Compact<u32>"},
+				{Name: "remark", Type: "Bytes"},
+			}},
+		}},
+		{Name: "ChildBounties", Calls: []CallMetadata{
+			{Name: "add_child_bounty", Index: 0, Args: []FieldMetadata{
+				{Name: "parent_bounty_id", Type: "Compact<u32>"},
+				{Name: "value", Type: "Compact<u128>"},
+				{Name: "description", Type: "Bytes"},
+			}},
+			{Name: "propose_curator", Index: 1, Args: []FieldMetadata{
+				{Name: "parent_bounty_id", Type: "Compact<u32>"},
+				{Name: "child_bounty_id", Type: "Compact<u32>"},
+				{Name: "curator", Type: "MultiAddress"},
+				{Name: "fee", Type: "Compact<u128>"},
+			}},
+			{Name: "accept_curator", Index: 2, Args: []FieldMetadata{
+				{Name: "parent_bounty_id", Type: "Compact<u32>"},
+				{Name: "child_bounty_id", Type: "Compact<u32>"},
+			}},
+			{Name: "unassign_curator", Index: 3, Args: []FieldMetadata{
+				{Name: "parent_bounty_id", Type: "Compact<u32>"},
+				{Name: "child_bounty_id", Type: "Compact<u32>"},
+			}},
+			{Name: "award_child_bounty", Index: 4, Args: []FieldMetadata{
+				{Name: "parent_bounty_id", Type: "Compact<u32>"},
+				{Name: "child_bounty_id", Type: "Compact<u32>"},
+				{Name: "beneficiary", Type: "MultiAddress"},
+			}},
+			{Name: "claim_child_bounty", Index: 5, Args: []FieldMetadata{
+				{Name: "parent_bounty_id", Type: "Compact<u32>"},
+				{Name: "child_bounty_id", Type: "Compact<u32>"},
+			}},
+			{Name: "close_child_bounty", Index: 6, Args: []FieldMetadata{
+				{Name: "parent_bounty_id", Type: "Compact<u32>"},
+				{Name: "child_bounty_id", Type: "Compact<u32>"},
+			}},
+		}},
+	}
+}
+
+// xcmTypes are the XCM v3/v4 location types used by treasury.spend. v3 and
+// v4 locations share their encoding.
+func xcmTypes() map[string]TypeDef {
+	junctions := []VariantMetadata{{Name: "Here", Index: 0}}
+	for n := 1; n <= 8; n++ {
+		elems := strings.TrimSuffix(strings.Repeat("Junction, ", n), ", ")
+		junctions = append(junctions, VariantMetadata{
+			Name:   fmt.Sprintf("X%d", n),
+			Index:  uint8(n),
+			Fields: []FieldMetadata{{Type: "(" + elems + ")"}},
+		})
+	}
+
+	return map[string]TypeDef{
+		"VersionedLocatableAsset": {Variants: []VariantMetadata{
+			{Name: "V3", Index: 3, Fields: []FieldMetadata{
+				{Name: "location", Type: "Location"},
+				{Name: "asset_id", Type: "AssetIdV3"},
+			}},
+			{Name: "V4", Index: 4, Fields: []FieldMetadata{
+				{Name: "location", Type: "Location"},
+				{Name: "asset_id", Type: "Location"},
+			}},
+		}},
+		"VersionedLocation": {Variants: []VariantMetadata{
+			{Name: "V3", Index: 3, Fields: []FieldMetadata{{Type: "Location"}}},
+			{Name: "V4", Index: 4, Fields: []FieldMetadata{{Type: "Location"}}},
+		}},
+		"AssetIdV3": {Variants: []VariantMetadata{
+			{Name: "Concrete", Index: 0, Fields: []FieldMetadata{{Type: "Location"}}},
+			{Name: "Abstract", Index: 1, Fields: []FieldMetadata{{Type: "[u8; 32]"}}},
+		}},
+		"Location": {Fields: []FieldMetadata{
+			{Name: "parents", Type: "u8"},
+			{Name: "interior", Type: "Junctions"},
+		}},
+		"Junctions": {Variants: junctions},
+		"Junction": {Variants: []VariantMetadata{
+			{Name: "Parachain", Index: 0, Fields: []FieldMetadata{{Type: "Compact<u32>"}}},
+			{Name: "AccountId32", Index: 1, Fields: []FieldMetadata{
+				{Name: "network", Type: "Option<NetworkId>"},
+				{Name: "id", Type: "AccountId32"},
+			}},
+			{Name: "AccountIndex64", Index: 2, Fields: []FieldMetadata{
+				{Name: "network", Type: "Option<NetworkId>"},
+				{Name: "index", Type: "Compact<u64>"},
+			}},
+			{Name: "AccountKey20", Index: 3, Fields: []FieldMetadata{
+				{Name: "network", Type: "Option<NetworkId>"},
+				{Name: "key", Type: "[u8; 20]"},
+			}},
+			{Name: "PalletInstance", Index: 4, Fields: []FieldMetadata{{Type: "u8"}}},
+			{Name: "GeneralIndex", Index: 5, Fields: []FieldMetadata{{Type: "Compact<u128>"}}},
+			{Name: "GeneralKey", Index: 6, Fields: []FieldMetadata{
+				{Name: "length", Type: "u8"},
+				{Name: "data", Type: "[u8; 32]"},
+			}},
+			{Name: "OnlyChild", Index: 7},
+			{Name: "Plurality", Index: 8, Fields: []FieldMetadata{
+				{Name: "id", Type: "BodyId"},
+				{Name: "part", Type: "BodyPart"},
+			}},
+			{Name: "GlobalConsensus", Index: 9, Fields: []FieldMetadata{{Type: "NetworkId"}}},
+		}},
+		"NetworkId": {Variants: []VariantMetadata{
+			{Name: "ByGenesis", Index: 0, Fields: []FieldMetadata{{Type: "[u8; 32]"}}},
+			{Name: "ByFork", Index: 1, Fields: []FieldMetadata{
+				{Name: "block_number", Type: "u64"},
+				{Name: "block_hash", Type: "[u8; 32]"},
+			}},
+			{Name: "Polkadot", Index: 2},
+			{Name: "Kusama", Index: 3},
+			{Name: "Westend", Index: 4},
+			{Name: "Rococo", Index: 5},
+			{Name: "Wococo", Index: 6},
+			{Name: "Ethereum", Index: 7, Fields: []FieldMetadata{{Name: "chain_id", Type: "Compact<u64>"}}},
+			{Name: "BitcoinCore", Index: 8},
+			{Name: "BitcoinCash", Index: 9},
+			{Name: "PolkadotBulletin", Index: 10},
+		}},
+		"BodyId": {Variants: []VariantMetadata{
+			{Name: "Unit", Index: 0},
+			{Name: "Moniker", Index: 1, Fields: []FieldMetadata{{Type: "[u8; 4]"}}},
+			{Name: "Index", Index: 2, Fields: []FieldMetadata{{Type: "Compact<u32>"}}},
+			{Name: "Executive", Index: 3},
+			{Name: "Technical", Index: 4},
+			{Name: "Legislative", Index: 5},
+			{Name: "Judicial", Index: 6},
+			{Name: "Defense", Index: 7},
+			{Name: "Administration", Index: 8},
+			{Name: "Treasury", Index: 9},
+		}},
+		"BodyPart": {Variants: []VariantMetadata{
+			{Name: "Voice", Index: 0},
+			{Name: "Members", Index: 1, Fields: []FieldMetadata{{Name: "count", Type: "Compact<u32>"}}},
+			{Name: "Fraction", Index: 2, Fields: []FieldMetadata{
+				{Name: "nom", Type: "Compact<u32>"},
+				{Name: "denom", Type: "Compact<u32>"},
+			}},
+			{Name: "AtLeastProportion", Index: 3, Fields: []FieldMetadata{
+				{Name: "nom", Type: "Compact<u32>"},
+				{Name: "denom", Type: "Compact<u32>"},
+			}},
+			{Name: "MoreThanProportion", Index: 4, Fields: []FieldMetadata{
+				{Name: "nom", Type: "Compact<u32>"},
+				{Name: "denom", Type: "Compact<u32>"},
+			}},
+		}},
+	}
+}
@@ -0,0 +1,222 @@
+package polkassembly
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Report types
+const (
+	ReportTypePost    = "post"
+	ReportTypeComment = "comment"
+)
+
+// Report reasons
+const (
+	ReportReasonSpam     = "spam"
+	ReportReasonAbusive  = "abusive"
+	ReportReasonPhishing = "phishing"
+	ReportReasonOffTopic = "off-topic"
+	ReportReasonOther    = "other"
+)
+
+// ErrPermissionDenied is wrapped by the errors of actions the account is not
+// allowed to perform, e.g. moderation without the moderator role
+var ErrPermissionDenied = errors.New("permission denied")
+
+// PermissionError is returned when the API rejects an action with 401 or 403
+type PermissionError struct {
+	Action     string
+	StatusCode int
+	Message    string
+}
+
+func (e *PermissionError) Error() string {
+	msg := fmt.Sprintf("%s: permission denied (HTTP %d)", e.Action, e.StatusCode)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+func (e *PermissionError) Unwrap() error {
+	return ErrPermissionDenied
+}
+
+// CreateReport reports a post or a comment
+func (c *Client) CreateReport(req CreateReportRequest) (*Report, error) {
+	if req.Type == "" {
+		req.Type = ReportTypePost
+		if req.CommentID != "" {
+			req.Type = ReportTypeComment
+		}
+	}
+	if err := validateReport(req); err != nil {
+		return nil, err
+	}
+	path, err := proposalPath(req.ProposalType, ProposalTypeReferendumV2)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("/%s/%d/reports", path, req.ContentID)
+	if req.Type == ReportTypeComment {
+		endpoint = fmt.Sprintf("/%s/%d/comments/%s/reports", path, req.ContentID, req.CommentID)
+	}
+
+	r, err := c.client.R().
+		SetBody(req).
+		Post(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp Report
+	if err := c.parsePermissioned(r, "report "+req.Type, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ReportPost reports a post, e.g. as spam
+func (c *Client) ReportPost(proposalType ProposalType, postID int, reason, comments string) (*Report, error) {
+	return c.CreateReport(CreateReportRequest{
+		Type:         ReportTypePost,
+		ContentID:    postID,
+		ProposalType: proposalType,
+		Reason:       reason,
+		Comments:     comments,
+	})
+}
+
+// ReportComment reports a comment on a post
+func (c *Client) ReportComment(proposalType ProposalType, postID int, commentID, reason, comments string) (*Report, error) {
+	return c.CreateReport(CreateReportRequest{
+		Type:         ReportTypeComment,
+		ContentID:    postID,
+		ProposalType: proposalType,
+		CommentID:    commentID,
+		Reason:       reason,
+		Comments:     comments,
+	})
+}
+
+// GetUserReports lists the reports filed by a user
+func (c *Client) GetUserReports(userID int, page, limit int) ([]Report, error) {
+	queryParams := make(map[string]string)
+	if page > 0 {
+		queryParams["page"] = fmt.Sprintf("%d", page)
+	}
+	if limit > 0 {
+		queryParams["limit"] = fmt.Sprintf("%d", limit)
+	}
+
+	r, err := c.client.R().
+		SetQueryParams(queryParams).
+		Get(fmt.Sprintf("/users/id/%d/reports", userID))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := permissionError(r, "list reports"); err != nil {
+		return nil, err
+	}
+	var resp []Report
+	if err := c.parseListResponse(r, "reports", &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// HideComment hides a comment from other users. Requires the moderator role.
+func (c *Client) HideComment(proposalType ProposalType, postID int, commentID string) error {
+	return c.moderateComment(proposalType, postID, commentID, "hide", "hide comment")
+}
+
+// UnhideComment makes a hidden comment visible again. Requires the moderator role.
+func (c *Client) UnhideComment(proposalType ProposalType, postID int, commentID string) error {
+	return c.moderateComment(proposalType, postID, commentID, "unhide", "unhide comment")
+}
+
+// MarkCommentSpam flags a comment as spam. Requires the moderator role.
+func (c *Client) MarkCommentSpam(proposalType ProposalType, postID int, commentID string) error {
+	return c.moderateComment(proposalType, postID, commentID, "spam", "mark comment as spam")
+}
+
+// MarkPostSpam flags a post as spam. Requires the moderator role.
+func (c *Client) MarkPostSpam(proposalType ProposalType, postID int) error {
+	path, err := proposalPath(proposalType, ProposalTypeReferendumV2)
+	if err != nil {
+		return err
+	}
+
+	r, err := c.client.R().
+		Post(fmt.Sprintf("/%s/%d/spam", path, postID))
+	if err != nil {
+		return err
+	}
+
+	return c.parsePermissioned(r, "mark post as spam", nil)
+}
+
+func (c *Client) moderateComment(proposalType ProposalType, postID int, commentID, action, desc string) error {
+	path, err := proposalPath(proposalType, ProposalTypeReferendumV2)
+	if err != nil {
+		return err
+	}
+	if commentID == "" {
+		return fmt.Errorf("%s: comment id is required", desc)
+	}
+
+	r, err := c.client.R().
+		Post(fmt.Sprintf("/%s/%d/comments/%s/%s", path, postID, commentID, action))
+	if err != nil {
+		return err
+	}
+
+	return c.parsePermissioned(r, desc, nil)
+}
+
+// parsePermissioned is parseResponse with 401 and 403 reported as a
+// PermissionError for action
+func (c *Client) parsePermissioned(r *resty.Response, action string, v interface{}) error {
+	if err := permissionError(r, action); err != nil {
+		return err
+	}
+	return c.parseResponse(r, v)
+}
+
+func permissionError(r *resty.Response, action string) error {
+	if r.StatusCode() != 401 && r.StatusCode() != 403 {
+		return nil
+	}
+
+	var apiErr APIError
+	if err := json.Unmarshal(r.Body(), &apiErr); err != nil {
+		apiErr.Message = strings.TrimSpace(string(r.Body()))
+	}
+	return &PermissionError{Action: action, StatusCode: r.StatusCode(), Message: apiErr.Error()}
+}
+
+func validateReport(req CreateReportRequest) error {
+	switch req.Type {
+	case ReportTypePost:
+	case ReportTypeComment:
+		if req.CommentID == "" {
+			return fmt.Errorf("comment report needs a comment id")
+		}
+	default:
+		return fmt.Errorf("invalid report type: %q", req.Type)
+	}
+	if req.ContentID <= 0 {
+		return fmt.Errorf("report needs a post id")
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		return fmt.Errorf("report reason is required")
+	}
+	return nil
+}
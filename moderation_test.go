@@ -0,0 +1,53 @@
+package polkassembly
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+)
+
+func TestValidateReport(t *testing.T) {
+	valid := []CreateReportRequest{
+		{Type: ReportTypePost, ContentID: 12, Reason: ReportReasonSpam},
+		{Type: ReportTypeComment, ContentID: 12, CommentID: "abc", Reason: ReportReasonAbusive},
+	}
+	for i, req := range valid {
+		if err := validateReport(req); err != nil {
+			t.Errorf("request %d: unexpected error: %v", i, err)
+		}
+	}
+
+	invalid := []CreateReportRequest{
+		{Type: "user", ContentID: 12, Reason: ReportReasonSpam},
+		{Type: ReportTypeComment, ContentID: 12, Reason: ReportReasonSpam},
+		{Type: ReportTypePost, Reason: ReportReasonSpam},
+		{Type: ReportTypePost, ContentID: 12, Reason: " "},
+	}
+	for i, req := range invalid {
+		if err := validateReport(req); err == nil {
+			t.Errorf("request %d: expected an error", i)
+		}
+	}
+}
+
+func TestPermissionError(t *testing.T) {
+	forbidden := (&resty.Response{RawResponse: &http.Response{StatusCode: 403}}).
+		SetBody([]byte(`{"message":"Only moderators can hide comments"}`))
+
+	err := permissionError(forbidden, "hide comment")
+	if !errors.Is(err, ErrPermissionDenied) {
+		t.Fatalf("expected ErrPermissionDenied, got %v", err)
+	}
+	var permErr *PermissionError
+	if !errors.As(err, &permErr) || permErr.StatusCode != 403 || !strings.Contains(err.Error(), "Only moderators") {
+		t.Errorf("unexpected permission error: %v", err)
+	}
+
+	ok := &resty.Response{RawResponse: &http.Response{StatusCode: 200}}
+	if err := permissionError(ok, "hide comment"); err != nil {
+		t.Errorf("unexpected error for 200: %v", err)
+	}
+}
@@ -0,0 +1,257 @@
+package polkassembly
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// maxNotificationPages bounds how far a NotificationIterator pages back
+const maxNotificationPages = 20
+
+// GetNotifications lists the notifications of a user, newest first
+func (c *Client) GetNotifications(userID int, params NotificationListingParams) ([]Notification, error) {
+	queryParams := make(map[string]string)
+	if params.Page > 0 {
+		queryParams["page"] = fmt.Sprintf("%d", params.Page)
+	}
+	if params.Limit > 0 {
+		queryParams["limit"] = fmt.Sprintf("%d", params.Limit)
+	}
+	if params.UnreadOnly {
+		queryParams["unread"] = "true"
+	}
+
+	r, err := c.client.R().
+		SetQueryParams(queryParams).
+		Get(fmt.Sprintf("/users/id/%d/notifications", userID))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []Notification
+	if err := c.parseListResponse(r, "notifications", &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// MarkNotificationRead marks a single notification as read
+func (c *Client) MarkNotificationRead(userID, notificationID int) error {
+	r, err := c.client.R().
+		SetBody(map[string]interface{}{"is_read": true}).
+		Patch(fmt.Sprintf("/users/id/%d/notifications/%d", userID, notificationID))
+
+	if err != nil {
+		return err
+	}
+
+	return c.parseResponse(r, nil)
+}
+
+// MarkAllNotificationsRead marks every notification of a user as read
+func (c *Client) MarkAllNotificationsRead(userID int) error {
+	r, err := c.client.R().
+		Post(fmt.Sprintf("/users/id/%d/notifications/mark-all-read", userID))
+
+	if err != nil {
+		return err
+	}
+
+	return c.parseResponse(r, nil)
+}
+
+// GetNotificationPreferences retrieves the preferences of a user on network,
+// or on the client network when network is empty
+func (c *Client) GetNotificationPreferences(userID int, network string) (*NotificationPreferences, error) {
+	if network == "" {
+		network = c.network
+	}
+
+	r, err := c.client.R().
+		SetQueryParam("network", network).
+		Get(fmt.Sprintf("/users/id/%d/notification-preferences", userID))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp NotificationPreferences
+	if err := c.parseResponse(r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateNotificationPreferences replaces the preferences of a user on
+// network, or on the client network when network is empty
+func (c *Client) UpdateNotificationPreferences(userID int, network string, prefs NotificationPreferences) (*NotificationPreferences, error) {
+	if network == "" {
+		network = c.network
+	}
+
+	r, err := c.client.R().
+		SetQueryParam("network", network).
+		SetBody(prefs).
+		Put(fmt.Sprintf("/users/id/%d/notification-preferences", userID))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp NotificationPreferences
+	if err := c.parseResponse(r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// NotificationCursor marks the newest notification already seen. It can be
+// stored as JSON between runs.
+type NotificationCursor struct {
+	CreatedAt time.Time `json:"createdAt"`
+	ID        int       `json:"id"`
+}
+
+// Before reports whether n is newer than the cursor. Notifications created
+// at the same time are ordered by ID.
+func (cur NotificationCursor) Before(n Notification) bool {
+	if n.CreatedAt.Equal(cur.CreatedAt) {
+		return n.ID > cur.ID
+	}
+	return n.CreatedAt.After(cur.CreatedAt)
+}
+
+// NotificationIterator yields notifications newer than a cursor, e.g. for a
+// bot that polls and forwards new notifications
+type NotificationIterator struct {
+	c      *Client
+	userID int
+	cursor NotificationCursor
+	// PageSize is the number of notifications requested per page
+	PageSize int
+	// UnreadOnly skips notifications that were already read
+	UnreadOnly bool
+
+	gap *notificationGap
+}
+
+// notificationGap tracks the notifications between the cursor and the oldest
+// one returned that a truncated Next has not fetched yet
+type notificationGap struct {
+	until  NotificationCursor // oldest notification returned
+	newest NotificationCursor // newest notification returned
+	page   int                // page to resume from
+}
+
+// NewNotificationIterator starts iterating after cursor. A zero cursor yields
+// every notification on the first call.
+func (c *Client) NewNotificationIterator(userID int, cursor NotificationCursor) *NotificationIterator {
+	return &NotificationIterator{c: c, userID: userID, cursor: cursor, PageSize: 50}
+}
+
+// Next returns the notifications created since the last call, oldest first,
+// and advances the cursor past them. When more than maxNotificationPages
+// pages are new, only the newest are returned, Truncated reports true and the
+// cursor stays put; the following calls return the older ones in between
+// before moving the cursor past everything returned.
+func (it *NotificationIterator) Next() ([]Notification, error) {
+	first := 1
+	if it.gap != nil {
+		first = it.gap.page
+	}
+
+	var fetched []Notification
+	caughtUp := false
+	page := first
+	for ; page < first+maxNotificationPages; page++ {
+		batch, err := it.c.GetNotifications(it.userID, NotificationListingParams{
+			Page:  page,
+			Limit: it.PageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		fetched = append(fetched, batch...)
+		// Pages are newest first, so an older notification means we caught up
+		if len(notificationsAfter(batch, it.cursor)) < len(batch) || it.PageSize <= 0 || len(batch) < it.PageSize {
+			caughtUp = true
+			break
+		}
+	}
+
+	fresh := notificationsAfter(fetched, it.cursor)
+	newest := it.cursor
+	if it.gap != nil {
+		// Skip what was returned before; newer arrivals wait for the next call
+		var older []Notification
+		for _, n := range fresh {
+			if !it.gap.until.Before(n) && n.ID != it.gap.until.ID {
+				older = append(older, n)
+			}
+		}
+		fresh = older
+		newest = it.gap.newest
+	} else if len(fresh) > 0 {
+		last := fresh[len(fresh)-1]
+		newest = NotificationCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	if caughtUp {
+		it.cursor = newest
+		it.gap = nil
+	} else {
+		it.c.logDebug("Stopped after %d notification pages without reaching the cursor", page-1)
+		if it.gap == nil {
+			it.gap = &notificationGap{newest: newest}
+		}
+		if len(fresh) > 0 {
+			it.gap.until = NotificationCursor{CreatedAt: fresh[0].CreatedAt, ID: fresh[0].ID}
+		}
+		it.gap.page = page
+	}
+	if !it.UnreadOnly {
+		return fresh, nil
+	}
+
+	var unread []Notification
+	for _, n := range fresh {
+		if !n.IsRead {
+			unread = append(unread, n)
+		}
+	}
+	return unread, nil
+}
+
+// Cursor returns the position up to which every notification was returned.
+// While Truncated it stays behind the notifications already returned, so a
+// stored cursor may repeat some of them but never skips any.
+func (it *NotificationIterator) Cursor() NotificationCursor {
+	return it.cursor
+}
+
+// Truncated reports whether the last call to Next hit the page limit before
+// reaching the cursor, so notifications between the cursor and the oldest one
+// returned are still to come from the next call
+func (it *NotificationIterator) Truncated() bool {
+	return it.gap != nil
+}
+
+// notificationsAfter returns the notifications newer than cursor, oldest
+// first and without duplicates
+func notificationsAfter(notifications []Notification, cursor NotificationCursor) []Notification {
+	seen := make(map[int]bool)
+	var newer []Notification
+	for _, n := range notifications {
+		if cursor.Before(n) && !seen[n.ID] {
+			seen[n.ID] = true
+			newer = append(newer, n)
+		}
+	}
+	sort.SliceStable(newer, func(i, j int) bool {
+		if newer[i].CreatedAt.Equal(newer[j].CreatedAt) {
+			return newer[i].ID < newer[j].ID
+		}
+		return newer[i].CreatedAt.Before(newer[j].CreatedAt)
+	})
+	return newer
+}
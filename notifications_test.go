@@ -0,0 +1,104 @@
+package polkassembly
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestNotificationsAfter(t *testing.T) {
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	notifications := []Notification{
+		{ID: 4, CreatedAt: base.Add(2 * time.Minute)},
+		{ID: 3, CreatedAt: base.Add(time.Minute)},
+		{ID: 2, CreatedAt: base},
+		{ID: 1, CreatedAt: base},
+		{ID: 3, CreatedAt: base.Add(time.Minute)},
+	}
+
+	cursor := NotificationCursor{CreatedAt: base, ID: 1}
+	newer := notificationsAfter(notifications, cursor)
+	if len(newer) != 3 || newer[0].ID != 2 || newer[1].ID != 3 || newer[2].ID != 4 {
+		t.Errorf("unexpected notifications after cursor: %+v", newer)
+	}
+
+	if all := notificationsAfter(notifications, NotificationCursor{}); len(all) != 4 {
+		t.Errorf("expected every notification after a zero cursor, got %d", len(all))
+	}
+	if none := notificationsAfter(notifications, NotificationCursor{CreatedAt: base.Add(2 * time.Minute), ID: 4}); len(none) != 0 {
+		t.Errorf("expected no notifications, got %+v", none)
+	}
+}
+
+func TestNotificationIteratorNext(t *testing.T) {
+	base := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	var notifications []Notification // newest first
+	add := func(id int) {
+		n := Notification{ID: id, CreatedAt: base.Add(time.Duration(id) * time.Minute)}
+		notifications = append([]Notification{n}, notifications...)
+	}
+	for id := 1; id <= 5; id++ {
+		add(id)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/id/7/notifications" {
+			http.NotFound(w, r)
+			return
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		start, end := pageBounds(len(notifications), page, limit)
+		json.NewEncoder(w).Encode(map[string]interface{}{"items": notifications[start:end]})
+	}))
+	defer server.Close()
+
+	c := NewClient(Config{BaseURL: server.URL, Network: "polkadot"})
+	it := c.NewNotificationIterator(7, NotificationCursor{CreatedAt: base.Add(2 * time.Minute), ID: 2})
+	it.PageSize = 2
+
+	got, err := it.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 || got[0].ID != 3 || got[2].ID != 5 || it.Truncated() {
+		t.Fatalf("unexpected first batch: %+v (truncated %v)", got, it.Truncated())
+	}
+	if it.Cursor().ID != 5 {
+		t.Errorf("expected cursor at 5, got %+v", it.Cursor())
+	}
+
+	add(6)
+	if got, err = it.Next(); err != nil || len(got) != 1 || got[0].ID != 6 {
+		t.Fatalf("unexpected second batch: %+v, %v", got, err)
+	}
+
+	// More new notifications than the page limit covers
+	for id := 7; id <= 6+maxNotificationPages*2+1; id++ {
+		add(id)
+	}
+	if got, err = it.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != maxNotificationPages*2 || got[0].ID != 8 || !it.Truncated() {
+		t.Errorf("expected %d notifications and a truncated window, got %d (truncated %v)", maxNotificationPages*2, len(got), it.Truncated())
+	}
+	if it.Cursor().ID != 6 {
+		t.Errorf("expected the cursor to stay at 6, got %+v", it.Cursor())
+	}
+
+	// The next call fills the gap even when newer notifications shift the pages
+	add(6 + maxNotificationPages*2 + 2)
+	if got, err = it.Next(); err != nil || len(got) != 1 || got[0].ID != 7 || it.Truncated() {
+		t.Fatalf("expected the skipped notification, got %+v, %v (truncated %v)", got, err, it.Truncated())
+	}
+	if it.Cursor().ID != 6+maxNotificationPages*2+1 {
+		t.Errorf("expected the cursor past the returned notifications, got %+v", it.Cursor())
+	}
+	if got, err = it.Next(); err != nil || len(got) != 1 || got[0].ID != 6+maxNotificationPages*2+2 {
+		t.Errorf("expected the notification added meanwhile, got %+v, %v", got, err)
+	}
+}
@@ -0,0 +1,192 @@
+package polkassembly
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Poll statuses
+const (
+	PollStatusOpen   = "open"
+	PollStatusClosed = "closed"
+)
+
+// PollTally is the outcome of a poll. Winners holds every option with the
+// highest vote count, so a tie has more than one winner.
+type PollTally struct {
+	Total   int          `json:"total"`
+	Winners []PollOption `json:"winners"`
+	Tie     bool         `json:"tie"`
+}
+
+// CreatePoll creates a poll on a post
+func (c *Client) CreatePoll(proposalType ProposalType, postID int, req CreatePollRequest) (*Poll, error) {
+	path, err := proposalPath(proposalType, ProposalTypeDiscussion)
+	if err != nil {
+		return nil, err
+	}
+	if err := validatePollRequest(req, time.Now()); err != nil {
+		return nil, err
+	}
+	req.PostID = postID
+
+	r, err := c.client.R().
+		SetBody(req).
+		Post(fmt.Sprintf("/%s/%d/polls", path, postID))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp Poll
+	if err := c.parseResponse(r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetPolls lists the polls on a post with their tallies recomputed
+func (c *Client) GetPolls(proposalType ProposalType, postID int) ([]Poll, error) {
+	path, err := proposalPath(proposalType, ProposalTypeDiscussion)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := c.client.R().
+		Get(fmt.Sprintf("/%s/%d/polls", path, postID))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []Poll
+	if err := c.parseListResponse(r, "polls", &resp); err != nil {
+		return nil, err
+	}
+	for i := range resp {
+		TallyPoll(&resp[i])
+	}
+	return resp, nil
+}
+
+// GetPollResults fetches a poll and tallies its votes
+func (c *Client) GetPollResults(proposalType ProposalType, postID, pollID int) (*Poll, *PollTally, error) {
+	path, err := proposalPath(proposalType, ProposalTypeDiscussion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r, err := c.client.R().
+		Get(fmt.Sprintf("/%s/%d/polls/%d", path, postID, pollID))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var resp Poll
+	if err := c.parseResponse(r, &resp); err != nil {
+		return nil, nil, err
+	}
+	tally := TallyPoll(&resp)
+	return &resp, &tally, nil
+}
+
+// VotePoll casts a vote on a poll. Voting again replaces the earlier vote.
+func (c *Client) VotePoll(proposalType ProposalType, postID, pollID int, req PollVoteRequest) (*Poll, error) {
+	path, err := proposalPath(proposalType, ProposalTypeDiscussion)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := c.client.R().
+		SetBody(req).
+		Put(fmt.Sprintf("/%s/%d/polls/%d/vote", path, postID, pollID))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp Poll
+	if err := c.parseResponse(r, &resp); err != nil {
+		return nil, err
+	}
+	TallyPoll(&resp)
+	return &resp, nil
+}
+
+// ClosePoll ends a poll before its end time
+func (c *Client) ClosePoll(proposalType ProposalType, postID, pollID int) (*Poll, error) {
+	path, err := proposalPath(proposalType, ProposalTypeDiscussion)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := c.client.R().
+		SetBody(map[string]interface{}{"status": PollStatusClosed}).
+		Patch(fmt.Sprintf("/%s/%d/polls/%d", path, postID, pollID))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp Poll
+	if err := c.parseResponse(r, &resp); err != nil {
+		return nil, err
+	}
+	TallyPoll(&resp)
+	return &resp, nil
+}
+
+// TallyPoll recomputes the percentage of every option from the vote counts
+// and returns the winning options
+func TallyPoll(p *Poll) PollTally {
+	var tally PollTally
+	most := 0
+	for _, o := range p.Options {
+		tally.Total += o.VoteCount
+		if o.VoteCount > most {
+			most = o.VoteCount
+		}
+	}
+
+	for i := range p.Options {
+		o := &p.Options[i]
+		o.Percentage = 0
+		if tally.Total > 0 {
+			o.Percentage = float64(o.VoteCount) * 100 / float64(tally.Total)
+		}
+		if most > 0 && o.VoteCount == most {
+			tally.Winners = append(tally.Winners, *o)
+		}
+	}
+	tally.Tie = len(tally.Winners) > 1
+	return tally
+}
+
+// Closed reports whether the poll was closed or has passed its end time
+func (p Poll) Closed(now time.Time) bool {
+	if strings.EqualFold(p.Status, PollStatusClosed) {
+		return true
+	}
+	return !p.EndAt.IsZero() && !now.Before(p.EndAt)
+}
+
+func validatePollRequest(req CreatePollRequest, now time.Time) error {
+	if strings.TrimSpace(req.Question) == "" {
+		return fmt.Errorf("poll question is required")
+	}
+	if len(req.Options) < 2 {
+		return fmt.Errorf("poll needs at least 2 options, got %d", len(req.Options))
+	}
+	seen := make(map[string]bool)
+	for _, option := range req.Options {
+		key := strings.ToLower(strings.TrimSpace(option))
+		if key == "" {
+			return fmt.Errorf("poll options must not be empty")
+		}
+		if seen[key] {
+			return fmt.Errorf("duplicate poll option: %q", option)
+		}
+		seen[key] = true
+	}
+	if !req.EndAt.IsZero() && !req.EndAt.After(now) {
+		return fmt.Errorf("poll end time %s is in the past", req.EndAt.Format(time.RFC3339))
+	}
+	return nil
+}
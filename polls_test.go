@@ -0,0 +1,55 @@
+package polkassembly
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTallyPoll(t *testing.T) {
+	poll := Poll{Options: []PollOption{
+		{ID: 1, Text: "Aye", VoteCount: 3, Percentage: 99},
+		{ID: 2, Text: "Nay", VoteCount: 1},
+		{ID: 3, Text: "Abstain", VoteCount: 0},
+	}}
+	tally := TallyPoll(&poll)
+	if tally.Total != 4 || tally.Tie || len(tally.Winners) != 1 || tally.Winners[0].ID != 1 {
+		t.Errorf("unexpected tally: %+v", tally)
+	}
+	if poll.Options[0].Percentage != 75 || poll.Options[1].Percentage != 25 {
+		t.Errorf("unexpected percentages: %+v", poll.Options)
+	}
+
+	poll.Options[1].VoteCount = 3
+	if tally := TallyPoll(&poll); !tally.Tie || len(tally.Winners) != 2 {
+		t.Errorf("expected a tie, got %+v", tally)
+	}
+
+	empty := Poll{Options: []PollOption{{ID: 1}, {ID: 2}}}
+	if tally := TallyPoll(&empty); tally.Total != 0 || len(tally.Winners) != 0 || tally.Tie {
+		t.Errorf("expected no winners without votes, got %+v", tally)
+	}
+}
+
+func TestValidatePollRequest(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	valid := CreatePollRequest{Question: "Fund it?", Options: []string{"Yes", "No"}, EndAt: now.Add(24 * time.Hour)}
+	if err := validatePollRequest(valid, now); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	invalid := []CreatePollRequest{
+		{Options: []string{"Yes", "No"}},
+		{Question: "Fund it?", Options: []string{"Yes"}},
+		{Question: "Fund it?", Options: []string{"Yes", " yes"}},
+		{Question: "Fund it?", Options: []string{"Yes", "No"}, EndAt: now.Add(-time.Hour)},
+	}
+	for i, req := range invalid {
+		if err := validatePollRequest(req, now); err == nil {
+			t.Errorf("request %d: expected an error", i)
+		}
+	}
+
+	if !(Poll{EndAt: now}).Closed(now) || (Poll{Status: PollStatusOpen}).Closed(now) {
+		t.Error("unexpected poll closed state")
+	}
+}
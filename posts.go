@@ -9,9 +9,9 @@ import (
 // The API expects: /api/v2/{proposalType}
 func (c *Client) GetPosts(params PostListingParams) (*PostListingResponse, error) {
 	// Default to ReferendumV2 if no type specified
-	proposalType := params.ProposalType
-	if proposalType == "" {
-		proposalType = "ReferendumV2"
+	path, err := proposalPath(params.ProposalType, ProposalTypeReferendumV2)
+	if err != nil {
+		return nil, err
 	}
 
 	queryParams := make(map[string]string)
@@ -30,14 +30,25 @@ func (c *Client) GetPosts(params PostListingParams) (*PostListingResponse, error
 	if params.TrackStatus != "" {
 		queryParams["status"] = params.TrackStatus
 	}
+	if params.SearchTerm != "" {
+		queryParams["search"] = params.SearchTerm
+	}
 	if params.Origin != "" {
-		queryParams["origin"] = params.Origin
+		// Accept track names such as "medium_spender" as well as origins
+		if track, err := TrackByOrigin(c.network, params.Origin); err == nil {
+			queryParams["origin"] = track.Origin
+			if params.TrackNo == 0 {
+				queryParams["trackNo"] = fmt.Sprintf("%d", track.ID)
+			}
+		} else {
+			queryParams["origin"] = params.Origin
+		}
 	}
 
 	// The API expects proposalType as the main path
 	r, err := c.client.R().
 		SetQueryParams(queryParams).
-		Get(fmt.Sprintf("/%s", proposalType))
+		Get(fmt.Sprintf("/%s", path))
 	if err != nil {
 		return nil, err
 	}
@@ -80,17 +91,18 @@ func (c *Client) GetPosts(params PostListingParams) (*PostListingResponse, error
 // GetPost retrieves a single post by ID
 // The API expects: /api/v2/{proposalType}/{postId}
 func (c *Client) GetPost(postID int) (*Post, error) {
-	return c.GetPostByType(postID, "ReferendumV2")
+	return c.GetPostByType(postID, ProposalTypeReferendumV2)
 }
 
 // GetPostByType retrieves a single post by ID and type
-func (c *Client) GetPostByType(postID int, proposalType string) (*Post, error) {
-	if proposalType == "" {
-		proposalType = "ReferendumV2"
+func (c *Client) GetPostByType(postID int, proposalType ProposalType) (*Post, error) {
+	path, err := proposalPath(proposalType, ProposalTypeReferendumV2)
+	if err != nil {
+		return nil, err
 	}
 
 	r, err := c.client.R().
-		Get(fmt.Sprintf("/%s/%d", proposalType, postID))
+		Get(fmt.Sprintf("/%s/%d", path, postID))
 	if err != nil {
 		return nil, err
 	}
@@ -121,13 +133,13 @@ func (c *Client) GetPostByType(postID int, proposalType string) (*Post, error) {
 
 // GetPostOnchainData retrieves onchain data for a post
 func (c *Client) GetPostOnchainData(postID int) (*PostOnchainData, error) {
-	return c.GetPostOnchainDataByType(postID, "ReferendumV2")
+	return c.GetPostOnchainDataByType(postID, ProposalTypeReferendumV2)
 }
 
 // GetPostOnchainDataByType retrieves onchain data for a post by type
-func (c *Client) GetPostOnchainDataByType(postID int, proposalType string) (*PostOnchainData, error) {
-	if proposalType == "" {
-		proposalType = "ReferendumV2"
+func (c *Client) GetPostOnchainDataByType(postID int, proposalType ProposalType) (*PostOnchainData, error) {
+	if _, err := proposalPath(proposalType, ProposalTypeReferendumV2); err != nil {
+		return nil, err
 	}
 
 	// v2 API returns onchain data in the main post endpoint
@@ -160,17 +172,18 @@ func (c *Client) GetPostOnchainDataByType(postID int, proposalType string) (*Pos
 
 // GetPostComments retrieves comments for a post
 func (c *Client) GetPostComments(postID int) ([]Comment, error) {
-	return c.GetPostCommentsByType(postID, "ReferendumV2")
+	return c.GetPostCommentsByType(postID, ProposalTypeReferendumV2)
 }
 
 // GetPostCommentsByType retrieves comments for a post by type
-func (c *Client) GetPostCommentsByType(postID int, proposalType string) ([]Comment, error) {
-	if proposalType == "" {
-		proposalType = "ReferendumV2"
+func (c *Client) GetPostCommentsByType(postID int, proposalType ProposalType) ([]Comment, error) {
+	path, err := proposalPath(proposalType, ProposalTypeReferendumV2)
+	if err != nil {
+		return nil, err
 	}
 
 	r, err := c.client.R().
-		Get(fmt.Sprintf("/%s/%d/comments", proposalType, postID))
+		Get(fmt.Sprintf("/%s/%d/comments", path, postID))
 	if err != nil {
 		return nil, err
 	}
@@ -194,17 +207,18 @@ func (c *Client) GetPostCommentsByType(postID int, proposalType string) ([]Comme
 
 // GetContentSummary retrieves AI-generated summary for a post
 func (c *Client) GetContentSummary(postID int) (*ContentSummary, error) {
-	return c.GetContentSummaryByType(postID, "ReferendumV2")
+	return c.GetContentSummaryByType(postID, ProposalTypeReferendumV2)
 }
 
 // GetContentSummaryByType retrieves AI-generated summary for a post by type
-func (c *Client) GetContentSummaryByType(postID int, proposalType string) (*ContentSummary, error) {
-	if proposalType == "" {
-		proposalType = "ReferendumV2"
+func (c *Client) GetContentSummaryByType(postID int, proposalType ProposalType) (*ContentSummary, error) {
+	path, err := proposalPath(proposalType, ProposalTypeReferendumV2)
+	if err != nil {
+		return nil, err
 	}
 
 	r, err := c.client.R().
-		Get(fmt.Sprintf("/%s/%d/content-summary", proposalType, postID))
+		Get(fmt.Sprintf("/%s/%d/content-summary", path, postID))
 	if err != nil {
 		return nil, err
 	}
@@ -245,13 +259,14 @@ func (c *Client) GetActivityFeed(page, limit int) ([]ActivityFeedItem, error) {
 	return resp.Items, nil
 }
 
-func (c *Client) IsSubscribed(proposalType string, postID int) (*SubscriptionStatus, error) {
-	if proposalType == "" {
-		proposalType = "ReferendumV2"
+func (c *Client) IsSubscribed(proposalType ProposalType, postID int) (*SubscriptionStatus, error) {
+	path, err := proposalPath(proposalType, ProposalTypeReferendumV2)
+	if err != nil {
+		return nil, err
 	}
 
 	r, err := c.client.R().
-		Get(fmt.Sprintf("/%s/%d/subscription", proposalType, postID))
+		Get(fmt.Sprintf("/%s/%d/subscription", path, postID))
 
 	if err != nil {
 		return nil, err
@@ -272,17 +287,26 @@ func (c *Client) IsSubscribed(proposalType string, postID int) (*SubscriptionSta
 }
 
 // CreateOffchainPost creates an offchain discussion post
-func (c *Client) CreateOffchainPost(proposalType string, req CreateOffchainPostRequest) (*Post, error) {
-	if proposalType == "" {
-		proposalType = "Discussion"
+func (c *Client) CreateOffchainPost(proposalType ProposalType, req CreateOffchainPostRequest) (*Post, error) {
+	path, err := proposalPath(proposalType, ProposalTypeDiscussion)
+	if err != nil {
+		return nil, err
+	}
+
+	body := map[string]interface{}{
+		"title":   req.Title,
+		"content": req.Content,
+	}
+	if req.TopicID > 0 {
+		body["topicId"] = req.TopicID
+	}
+	if len(req.Tags) > 0 {
+		body["tags"] = req.Tags
 	}
 
 	r, err := c.client.R().
-		SetBody(map[string]interface{}{
-			"title":   req.Title,
-			"content": req.Content,
-		}).
-		Post(fmt.Sprintf("/%s", proposalType))
+		SetBody(body).
+		Post(fmt.Sprintf("/%s", path))
 	if err != nil {
 		return nil, err
 	}
@@ -296,11 +320,16 @@ func (c *Client) CreateOffchainPost(proposalType string, req CreateOffchainPostR
 }
 
 // UpdatePost updates an existing post
-func (c *Client) UpdatePost(proposalType string, postID int, req UpdatePostRequest) (*Post, error) {
-	if proposalType == "" {
-		proposalType = "ReferendumV2"
+func (c *Client) UpdatePost(proposalType ProposalType, postID int, req UpdatePostRequest) (*Post, error) {
+	path, err := proposalPath(proposalType, ProposalTypeReferendumV2)
+	if err != nil {
+		return nil, err
 	}
+	return c.patchPost(fmt.Sprintf("/%s/%d", path, postID), req)
+}
 
+// patchPost edits the title, content and tags of the post at path
+func (c *Client) patchPost(path string, req UpdatePostRequest) (*Post, error) {
 	body := make(map[string]interface{})
 	if req.Title != "" {
 		body["title"] = req.Title
@@ -308,10 +337,13 @@ func (c *Client) UpdatePost(proposalType string, postID int, req UpdatePostReque
 	if req.Content != "" {
 		body["content"] = req.Content
 	}
+	if len(req.Tags) > 0 {
+		body["tags"] = req.Tags
+	}
 
 	r, err := c.client.R().
 		SetBody(body).
-		Patch(fmt.Sprintf("/%s/%d", proposalType, postID))
+		Patch(path)
 	if err != nil {
 		return nil, err
 	}
@@ -324,32 +356,15 @@ func (c *Client) UpdatePost(proposalType string, postID int, req UpdatePostReque
 	return &resp, nil
 }
 
-// GetChildBounties retrieves child bounties for a parent bounty
-func (c *Client) GetChildBounties(bountyID int) ([]Bounty, error) {
-	r, err := c.client.R().
-		Get(fmt.Sprintf("/Bounty/%d/child-bounties", bountyID))
+// GetPreimageForPost retrieves preimage for a specific post
+func (c *Client) GetPreimageForPost(proposalType ProposalType, postID int) (*Preimage, error) {
+	path, err := proposalPath(proposalType, ProposalTypeReferendumV2)
 	if err != nil {
 		return nil, err
 	}
 
-	var resp struct {
-		ChildBounties []Bounty `json:"child_bounties"`
-	}
-	if err := json.Unmarshal(r.Body(), &resp); err != nil {
-		return nil, fmt.Errorf("unmarshal bounties: %w", err)
-	}
-
-	return resp.ChildBounties, nil
-}
-
-// GetPreimageForPost retrieves preimage for a specific post
-func (c *Client) GetPreimageForPost(proposalType string, postID int) (*Preimage, error) {
-	if proposalType == "" {
-		proposalType = "ReferendumV2"
-	}
-
 	r, err := c.client.R().
-		Get(fmt.Sprintf("/%s/%d/preimage", proposalType, postID))
+		Get(fmt.Sprintf("/%s/%d/preimage", path, postID))
 	if err != nil {
 		return nil, err
 	}
@@ -358,6 +373,7 @@ func (c *Client) GetPreimageForPost(proposalType string, postID int) (*Preimage,
 	if err := c.parseResponse(r, &resp); err != nil {
 		return nil, err
 	}
+	c.decodePreimage(&resp)
 
 	return &resp, nil
 }
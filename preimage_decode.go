@@ -0,0 +1,133 @@
+package polkassembly
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/polkadot-go/polkassembly-api/scale"
+)
+
+// DecodeProposedCall turns the proposed call of a preimage into a call tree.
+// Hex call data is decoded with m; calls already decoded to JSON by the API
+// ({"section", "method", "args"} or {"callIndex", "args"}) are normalized,
+// using m when given to order arguments and resolve call indices.
+func DecodeProposedCall(m *Metadata, proposed interface{}) (*DecodedCall, error) {
+	switch v := proposed.(type) {
+	case string:
+		if m == nil {
+			return nil, fmt.Errorf("metadata is required to decode call data")
+		}
+		return DecodeCallHex(m, v)
+	case map[string]interface{}:
+		call, ok := jsonCall(m, v)
+		if !ok {
+			return nil, fmt.Errorf("proposed call has no section and method")
+		}
+		return call, nil
+	case nil:
+		return nil, fmt.Errorf("no proposed call")
+	}
+	return nil, fmt.Errorf("unsupported proposed call: %T", proposed)
+}
+
+// Decode decodes the proposed call of the preimage, falling back to its
+// section and method when the call itself is missing
+func (p *Preimage) Decode(m *Metadata) (*DecodedCall, error) {
+	if p.ProposedCall == nil && p.Section != "" && p.Method != "" {
+		return &DecodedCall{Pallet: p.Section, Method: p.Method}, nil
+	}
+	return DecodeProposedCall(m, p.ProposedCall)
+}
+
+// decodePreimage fills the decoded call of a preimage. Decoding failures
+// are logged and leave Call nil so the preimage itself is still returned.
+func (c *Client) decodePreimage(p *Preimage) {
+	m, err := c.Metadata()
+	if err != nil {
+		m = nil
+	}
+	call, err := p.Decode(m)
+	if err != nil {
+		c.logDebug("Could not decode preimage %s: %v", p.Hash, err)
+		return
+	}
+	p.Call = call
+}
+
+// jsonCall converts a call decoded to JSON by the API
+func jsonCall(m *Metadata, v map[string]interface{}) (*DecodedCall, bool) {
+	section, _ := v["section"].(string)
+	method, _ := v["method"].(string)
+
+	var cm *CallMetadata
+	if m != nil {
+		if index, ok := v["callIndex"].(string); ok {
+			b, err := hex.DecodeString(strings.TrimPrefix(index, "0x"))
+			if err == nil && len(b) == 2 {
+				if p, c, err := m.CallByIndex(b[0], b[1]); err == nil {
+					section, method = scale.CallName(p, c)
+					cm = c
+				}
+			}
+		}
+		if cm == nil && section != "" && method != "" {
+			if _, c, err := m.Call(section, method); err == nil {
+				cm = c
+			}
+		}
+	}
+	if section == "" || method == "" {
+		return nil, false
+	}
+
+	call := &DecodedCall{Pallet: section, Method: method}
+	args, _ := v["args"].(map[string]interface{})
+	if cm != nil {
+		for _, f := range cm.Args {
+			if value, ok := scale.LookupField(args, f.Name); ok {
+				call.Args = append(call.Args, DecodedArg{Name: f.Name, Type: f.Type, Value: jsonValue(m, value)})
+			}
+		}
+		return call, true
+	}
+
+	for _, name := range sortedKeys(args) {
+		call.Args = append(call.Args, DecodedArg{Name: name, Value: jsonValue(m, args[name])})
+	}
+	return call, true
+}
+
+// jsonValue converts nested calls in a JSON value into *DecodedCall
+func jsonValue(m *Metadata, v interface{}) interface{} {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		if _, hasArgs := x["args"]; hasArgs {
+			if call, ok := jsonCall(m, x); ok {
+				return call
+			}
+		}
+		fields := make([]DecodedArg, 0, len(x))
+		for _, name := range sortedKeys(x) {
+			fields = append(fields, DecodedArg{Name: name, Value: jsonValue(m, x[name])})
+		}
+		return fields
+	case []interface{}:
+		items := make([]interface{}, len(x))
+		for i, item := range x {
+			items[i] = jsonValue(m, item)
+		}
+		return items
+	}
+	return v
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,200 @@
+package polkassembly
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestDecodeCallRoundTrip(t *testing.T) {
+	m, err := DefaultMetadata("polkadot")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vote, err := VoteCall(100, DecisionAye, ConvictionLocked1x, CartAmount{Aye: "10000000000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	batch := BatchAll(vote, RemoveVoteCall(nil, 5))
+
+	data, err := EncodeCall(m, batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodeCall(m, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.String() != "utility.batchAll" {
+		t.Fatalf("unexpected call %s", decoded)
+	}
+	calls := decoded.Calls()
+	if len(calls) != 2 || calls[0].String() != "convictionVoting.vote" || calls[1].String() != "convictionVoting.removeVote" {
+		t.Fatalf("unexpected nested calls: %+v", calls)
+	}
+
+	accountVote, _ := calls[0].Arg("vote")
+	ev, ok := accountVote.(EnumValue)
+	if !ok || ev.Variant != "Standard" {
+		t.Fatalf("unexpected vote: %#v", accountVote)
+	}
+	if balance := ev.Value.([]DecodedArg)[1].Value; balance.(interface{ String() string }).String() != "10000000000" {
+		t.Errorf("unexpected balance %v", balance)
+	}
+	if class, _ := calls[1].Arg("class"); class != nil {
+		t.Errorf("expected no class, got %v", class)
+	}
+
+	// Decoded calls encode back to the same bytes
+	again, err := EncodeCall(m, Call{Pallet: decoded.Pallet, Method: decoded.Method, Args: map[string]interface{}{"calls": decoded.Args[0].Value}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(again) != hex.EncodeToString(data) {
+		t.Errorf("round trip mismatch: %x != %x", again, data)
+	}
+
+	if _, err := DecodeCall(m, append(data, 0)); err == nil {
+		t.Error("expected an error for trailing bytes")
+	}
+}
+
+func TestDecodeTreasurySpend(t *testing.T) {
+	m, err := DefaultMetadata("polkadot")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// USDT on Asset Hub: parachain 1000, pallet 50, asset 1984
+	usdt := []DecodedArg{
+		{Name: "parents", Value: 0},
+		{Name: "interior", Value: EnumValue{Variant: "X2", Value: []interface{}{
+			EnumValue{Variant: "PalletInstance", Value: 50},
+			EnumValue{Variant: "GeneralIndex", Value: 1984},
+		}}},
+	}
+	assetHub := []DecodedArg{
+		{Name: "parents", Value: 0},
+		{Name: "interior", Value: EnumValue{Variant: "X1", Value: []interface{}{EnumValue{Variant: "Parachain", Value: 1000}}}},
+	}
+	beneficiary := []DecodedArg{
+		{Name: "parents", Value: 0},
+		{Name: "interior", Value: EnumValue{Variant: "X1", Value: []interface{}{
+			EnumValue{Variant: "AccountId32", Value: map[string]interface{}{"network": nil, "id": aliceAddress}},
+		}}},
+	}
+
+	spend := Call{Pallet: "treasury", Method: "spend", Args: map[string]interface{}{
+		"asset_kind":  EnumValue{Variant: "V4", Value: map[string]interface{}{"location": assetHub, "asset_id": usdt}},
+		"amount":      "25000000000",
+		"beneficiary": EnumValue{Variant: "V4", Value: beneficiary},
+		"valid_from":  nil,
+	}}
+	callData, err := spend.Hex(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(callData, "0x1305") {
+		t.Errorf("unexpected call index in %s", callData)
+	}
+
+	decoded, err := DecodeCallHex(m, callData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pretty := decoded.Pretty()
+	for _, want := range []string{"treasury.spend", "Parachain(1000)", "GeneralIndex(1984)", "amount: 25000000000", "valid_from: None"} {
+		if !strings.Contains(pretty, want) {
+			t.Errorf("pretty output missing %q:\n%s", want, pretty)
+		}
+	}
+}
+
+func TestDecodeProposedCallJSON(t *testing.T) {
+	m, err := DefaultMetadata("kusama")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := &Preimage{ProposedCall: map[string]interface{}{
+		"section": "whitelist",
+		"method":  "dispatchWhitelistedCallWithPreimage",
+		"args": map[string]interface{}{
+			"call": map[string]interface{}{
+				"callIndex": "0x1802",
+				"args": map[string]interface{}{
+					"calls": []interface{}{
+						map[string]interface{}{"section": "system", "method": "remark", "args": map[string]interface{}{"remark": "0x6869"}},
+					},
+				},
+			},
+		},
+	}}
+
+	call, err := p.Decode(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	call.Walk(func(c *DecodedCall) { names = append(names, c.String()) })
+	if strings.Join(names, ",") != "whitelist.dispatchWhitelistedCallWithPreimage,utility.batchAll,system.remark" {
+		t.Errorf("unexpected call tree: %v", names)
+	}
+	if !strings.Contains(call.Pretty(), `remark: "hi"`) {
+		t.Errorf("expected readable remark:\n%s", call.Pretty())
+	}
+
+	fallback, err := (&Preimage{Section: "treasury", Method: "spend"}).Decode(nil)
+	if err != nil || fallback.String() != "treasury.spend" {
+		t.Errorf("unexpected fallback: %v, %v", fallback, err)
+	}
+}
+
+func TestDecodeChildBountyCalls(t *testing.T) {
+	m, err := DefaultMetadata("polkadot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice, err := AccountID(aliceAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ChildBounties is pallet 38 (0x26); parent bounty 5 and child bounty 2
+	// are compact encoded as 0x14 and 0x08
+	tests := []struct {
+		hex    string
+		method string
+		args   int
+	}{
+		{"0x260414" + "08" + "00" + hex.EncodeToString(alice), "childBounties.awardChildBounty", 3},
+		{"0x26051408", "childBounties.claimChildBounty", 2},
+		{"0x26061408", "childBounties.closeChildBounty", 2},
+		{"0x26021408", "childBounties.acceptCurator", 2},
+		{"0x26031408", "childBounties.unassignCurator", 2},
+	}
+	for _, tt := range tests {
+		call, err := DecodeCallHex(m, tt.hex)
+		if err != nil {
+			t.Errorf("decode %s: %v", tt.method, err)
+			continue
+		}
+		if call.String() != tt.method || len(call.Args) != tt.args {
+			t.Errorf("expected %s with %d args, got %s with %+v", tt.method, tt.args, call, call.Args)
+			continue
+		}
+		parent, _ := call.Arg("parent_bounty_id")
+		child, _ := call.Arg("child_bounty_id")
+		if plainNumber(parent) != "5" || plainNumber(child) != "2" {
+			t.Errorf("%s: unexpected bounty ids %v and %v", tt.method, parent, child)
+		}
+	}
+
+	award, _ := DecodeCallHex(m, tests[0].hex)
+	if beneficiary, _ := award.Arg("beneficiary"); !SameAccount(beneficiaryAddress(beneficiary), aliceAddress) {
+		t.Errorf("unexpected beneficiary %v", beneficiary)
+	}
+}
@@ -37,6 +37,7 @@ func (c *Client) GetPreimageByHash(hash string) (*Preimage, error) {
 	if err := c.parseResponse(r, &resp); err != nil {
 		return nil, err
 	}
+	c.decodePreimage(&resp)
 
 	return &resp, nil
 }
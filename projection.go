@@ -0,0 +1,277 @@
+package polkassembly
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ProjectionModel selects how vote curve history is extrapolated
+type ProjectionModel string
+
+const (
+	// ProjectionLinear fits a line through the whole history
+	ProjectionLinear ProjectionModel = "linear"
+	// ProjectionRecentTrend fits a line through the most recent points only
+	ProjectionRecentTrend ProjectionModel = "recent"
+)
+
+// Projection outlooks
+const (
+	OutlookLikelyPass = "likely_pass"
+	OutlookLikelyFail = "likely_fail"
+	OutlookContested  = "contested"
+)
+
+type ProjectionOptions struct {
+	Model ProjectionModel
+	// RecentPoints is the number of trailing points used by ProjectionRecentTrend (default 10)
+	RecentPoints int
+	// DecisionStartBlock defaults to the first point of the history
+	DecisionStartBlock int
+	// ContestedMargin is the margin in percentage points under which a projection
+	// is flagged as contested (default 5)
+	ContestedMargin float64
+	// Now anchors block estimates to wall-clock time (default time.Now())
+	Now time.Time
+}
+
+// ReferendumProjection is the extrapolated outcome of a referendum.
+// Percentages are in [0, 100].
+type ReferendumProjection struct {
+	Model         ProjectionModel `json:"model"`
+	Track         Track           `json:"track"`
+	CurrentBlock  int             `json:"currentBlock"`
+	DecisionStart int             `json:"decisionStart"`
+	DecisionEnd   int             `json:"decisionEnd"`
+
+	ApprovalNow   float64 `json:"approvalNow"`
+	SupportNow    float64 `json:"supportNow"`
+	ApprovalAtEnd float64 `json:"approvalAtEnd"`
+	SupportAtEnd  float64 `json:"supportAtEnd"`
+
+	// Per-block slopes of the fitted trends, in percentage points
+	ApprovalTrend float64 `json:"approvalTrend"`
+	SupportTrend  float64 `json:"supportTrend"`
+
+	// Blocks at which the projection first meets each curve, 0 if never
+	ApprovalCrossBlock int       `json:"approvalCrossBlock,omitempty"`
+	SupportCrossBlock  int       `json:"supportCrossBlock,omitempty"`
+	PassBlock          int       `json:"passBlock,omitempty"`
+	EstimatedPassAt    time.Time `json:"estimatedPassAt,omitempty"`
+
+	// Smallest projected margin over the required thresholds at the crossing or end
+	Margin  float64 `json:"margin"`
+	Outlook string  `json:"outlook"`
+}
+
+// ProjectReferendum extrapolates vote curve history to the end of the decision
+// period of track and estimates when the approval and support thresholds are met
+func ProjectReferendum(points []VotingCurveData, track Track, opts ProjectionOptions) (*ReferendumProjection, error) {
+	if len(points) == 0 {
+		return nil, fmt.Errorf("no vote curve data")
+	}
+	if opts.Model == "" {
+		opts.Model = ProjectionLinear
+	}
+	if opts.RecentPoints <= 0 {
+		opts.RecentPoints = 10
+	}
+	if opts.ContestedMargin <= 0 {
+		opts.ContestedMargin = 5
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	sorted := append([]VotingCurveData(nil), points...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].BlockNumber < sorted[j].BlockNumber })
+
+	blocks := make([]float64, len(sorted))
+	approval := make([]float64, len(sorted))
+	support := make([]float64, len(sorted))
+	for i, p := range sorted {
+		a, err := curveApproval(p)
+		if err != nil {
+			return nil, err
+		}
+		s, err := parsePercent(p.Support)
+		if err != nil {
+			return nil, err
+		}
+		blocks[i] = float64(p.BlockNumber)
+		approval[i] = a
+		support[i] = s
+	}
+
+	window := 0
+	switch opts.Model {
+	case ProjectionLinear:
+	case ProjectionRecentTrend:
+		if len(sorted) > opts.RecentPoints {
+			window = len(sorted) - opts.RecentPoints
+		}
+	default:
+		return nil, fmt.Errorf("unknown projection model: %s", opts.Model)
+	}
+
+	current := sorted[len(sorted)-1].BlockNumber
+	start := opts.DecisionStartBlock
+	if start == 0 {
+		start = sorted[0].BlockNumber
+	}
+
+	proj := &ReferendumProjection{
+		Model:         opts.Model,
+		Track:         track,
+		CurrentBlock:  current,
+		DecisionStart: start,
+		DecisionEnd:   start + track.DecisionPeriod,
+		ApprovalNow:   approval[len(approval)-1],
+		SupportNow:    support[len(support)-1],
+	}
+
+	proj.ApprovalTrend = slope(blocks[window:], approval[window:])
+	proj.SupportTrend = slope(blocks[window:], support[window:])
+
+	approvalAt := func(block int) float64 {
+		return clamp(proj.ApprovalNow+proj.ApprovalTrend*float64(block-current), 0, 100)
+	}
+	supportAt := func(block int) float64 {
+		return clamp(proj.SupportNow+proj.SupportTrend*float64(block-current), 0, 100)
+	}
+	fraction := func(block int) float64 {
+		return float64(block-start) / float64(track.DecisionPeriod)
+	}
+
+	proj.ApprovalAtEnd = approvalAt(proj.DecisionEnd)
+	proj.SupportAtEnd = supportAt(proj.DecisionEnd)
+
+	// Walk the remaining decision period in small steps
+	step := track.DecisionPeriod / 1000
+	if step < 1 {
+		step = 1
+	}
+	for block := current; block <= proj.DecisionEnd; block += step {
+		x := fraction(block)
+		approvalOK := approvalAt(block) >= track.ApprovalThreshold(x)*100
+		supportOK := supportAt(block) >= track.SupportThreshold(x)*100
+
+		if approvalOK && proj.ApprovalCrossBlock == 0 {
+			proj.ApprovalCrossBlock = block
+		}
+		if supportOK && proj.SupportCrossBlock == 0 {
+			proj.SupportCrossBlock = block
+		}
+		if approvalOK && supportOK {
+			proj.PassBlock = block
+			break
+		}
+	}
+
+	marginBlock := proj.DecisionEnd
+	if proj.PassBlock != 0 {
+		marginBlock = proj.PassBlock
+		proj.EstimatedPassAt = opts.Now.Add(BlocksToDuration(proj.PassBlock - current))
+	}
+	x := fraction(marginBlock)
+	proj.Margin = math.Min(
+		approvalAt(marginBlock)-track.ApprovalThreshold(x)*100,
+		supportAt(marginBlock)-track.SupportThreshold(x)*100,
+	)
+
+	switch {
+	case math.Abs(proj.Margin) < opts.ContestedMargin:
+		proj.Outlook = OutlookContested
+	case proj.PassBlock != 0:
+		proj.Outlook = OutlookLikelyPass
+	default:
+		proj.Outlook = OutlookLikelyFail
+	}
+
+	return proj, nil
+}
+
+// ProjectReferendum fetches a referendum and its vote curve history and projects its outcome
+func (c *Client) ProjectReferendum(postID int, opts ProjectionOptions) (*ReferendumProjection, error) {
+	post, err := c.GetPostByType(postID, ProposalTypeReferendumV2)
+	if err != nil {
+		return nil, err
+	}
+
+	track, err := postTrack(post, c.network)
+	if err != nil {
+		return nil, err
+	}
+
+	points, err := c.GetVotingCurveByType(postID, ProposalTypeReferendumV2)
+	if err != nil {
+		return nil, err
+	}
+
+	return ProjectReferendum(points, *track, opts)
+}
+
+// PrioritizeProjections orders projections by how close they are to their
+// thresholds, so the referenda where votes matter most come first
+func PrioritizeProjections(projections []*ReferendumProjection) {
+	sort.SliceStable(projections, func(i, j int) bool {
+		return math.Abs(projections[i].Margin) < math.Abs(projections[j].Margin)
+	})
+}
+
+// curveApproval returns aye / (aye + nay) of a curve point in percent
+func curveApproval(p VotingCurveData) (float64, error) {
+	aye, err := ParseBalance(p.AyeAmount)
+	if err != nil {
+		return 0, err
+	}
+	nay, err := ParseBalance(p.NayAmount)
+	if err != nil {
+		return 0, err
+	}
+	return ratio(aye, new(big.Int).Add(aye, nay)) * 100, nil
+}
+
+func parsePercent(s string) (float64, error) {
+	s = strings.TrimSuffix(strings.TrimSpace(s), "%")
+	if s == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid percentage: %q", s)
+	}
+	return v, nil
+}
+
+// slope returns the least-squares slope of y over x
+func slope(x, y []float64) float64 {
+	if len(x) < 2 {
+		return 0
+	}
+
+	var meanX, meanY float64
+	for i := range x {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= float64(len(x))
+	meanY /= float64(len(y))
+
+	var cov, varX float64
+	for i := range x {
+		dx := x[i] - meanX
+		cov += dx * (y[i] - meanY)
+		varX += dx * dx
+	}
+
+	if varX == 0 {
+		return 0
+	}
+	return cov / varX
+}
@@ -0,0 +1,52 @@
+package polkassembly
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestProjectReferendum(t *testing.T) {
+	track, err := TrackByID("polkadot", 33)
+	if err != nil {
+		t.Fatalf("TrackByID failed: %v", err)
+	}
+
+	// Approval flat at 75%, support growing 0.5 points per day for 5 days
+	var points []VotingCurveData
+	start := 20_000_000
+	for day := 0; day <= 5; day++ {
+		points = append(points, VotingCurveData{
+			BlockNumber: start + day*BlocksPerDay,
+			AyeAmount:   "750",
+			NayAmount:   "250",
+			Support:     fmt.Sprintf("%.2f", 0.5*float64(day)),
+		})
+	}
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	proj, err := ProjectReferendum(points, *track, ProjectionOptions{Now: now})
+	if err != nil {
+		t.Fatalf("ProjectReferendum failed: %v", err)
+	}
+
+	if proj.PassBlock == 0 || proj.Outlook == OutlookLikelyFail {
+		t.Errorf("expected projection to pass: %+v", proj)
+	}
+	if !proj.EstimatedPassAt.After(now) {
+		t.Errorf("expected pass in the future, got %s", proj.EstimatedPassAt)
+	}
+
+	// Approval collapsing under 50% can never pass
+	for i := range points {
+		points[i].AyeAmount = fmt.Sprintf("%d", 400-i*10)
+		points[i].NayAmount = "600"
+	}
+	proj, err = ProjectReferendum(points, *track, ProjectionOptions{Model: ProjectionRecentTrend, RecentPoints: 3, Now: now})
+	if err != nil {
+		t.Fatalf("ProjectReferendum failed: %v", err)
+	}
+	if proj.Outlook != OutlookLikelyFail {
+		t.Errorf("expected likely fail, got %s", proj.Outlook)
+	}
+}
@@ -0,0 +1,179 @@
+package polkassembly
+
+import "fmt"
+
+// ProposalType identifies a kind of Polkassembly post. Its value is also the
+// path segment used by the API, e.g. /ReferendumV2/{id}.
+type ProposalType string
+
+const (
+	ProposalTypeDiscussion            ProposalType = "Discussion"
+	ProposalTypeGrant                 ProposalType = "Grant"
+	ProposalTypeReferendumV2          ProposalType = "ReferendumV2"
+	ProposalTypeFellowshipReferendum  ProposalType = "FellowshipReferendum"
+	ProposalTypeReferendum            ProposalType = "Referendum"
+	ProposalTypeDemocracyProposal     ProposalType = "DemocracyProposal"
+	ProposalTypeTreasuryProposal      ProposalType = "TreasuryProposal"
+	ProposalTypeTip                   ProposalType = "Tip"
+	ProposalTypeBounty                ProposalType = "Bounty"
+	ProposalTypeChildBounty           ProposalType = "ChildBounty"
+	ProposalTypeCouncilMotion         ProposalType = "CouncilMotion"
+	ProposalTypeTechCommitteeProposal ProposalType = "TechCommitteeProposal"
+	ProposalTypeAllianceMotion        ProposalType = "AllianceMotion"
+	ProposalTypeAnnouncement          ProposalType = "Announcement"
+	ProposalTypeTechnicalPIPs         ProposalType = "TechnicalPIPs"
+	ProposalTypeUpgradePIPs           ProposalType = "UpgradePIPs"
+	ProposalTypeCommunityPIPs         ProposalType = "CommunityPIPs"
+	ProposalTypeAdvisoryCommittee     ProposalType = "AdvisoryCommittee"
+)
+
+// AllProposalTypes lists every known proposal type
+var AllProposalTypes = []ProposalType{
+	ProposalTypeDiscussion,
+	ProposalTypeGrant,
+	ProposalTypeReferendumV2,
+	ProposalTypeFellowshipReferendum,
+	ProposalTypeReferendum,
+	ProposalTypeDemocracyProposal,
+	ProposalTypeTreasuryProposal,
+	ProposalTypeTip,
+	ProposalTypeBounty,
+	ProposalTypeChildBounty,
+	ProposalTypeCouncilMotion,
+	ProposalTypeTechCommitteeProposal,
+	ProposalTypeAllianceMotion,
+	ProposalTypeAnnouncement,
+	ProposalTypeTechnicalPIPs,
+	ProposalTypeUpgradePIPs,
+	ProposalTypeCommunityPIPs,
+	ProposalTypeAdvisoryCommittee,
+}
+
+var (
+	offChainProposalTypes = []ProposalType{
+		ProposalTypeDiscussion,
+		ProposalTypeGrant,
+	}
+
+	openGovReferendumTypes = []ProposalType{
+		ProposalTypeReferendumV2,
+		ProposalTypeFellowshipReferendum,
+	}
+
+	// OpenGov networks also list the treasury types referenda fund
+	openGovProposalTypes = append(append([]ProposalType{}, openGovReferendumTypes...),
+		ProposalTypeTreasuryProposal,
+		ProposalTypeBounty,
+		ProposalTypeChildBounty,
+	)
+
+	// Gov1 types remain listed on relay chains for historical posts
+	gov1ProposalTypes = []ProposalType{
+		ProposalTypeReferendum,
+		ProposalTypeDemocracyProposal,
+		ProposalTypeTip,
+		ProposalTypeCouncilMotion,
+		ProposalTypeTechCommitteeProposal,
+	}
+
+	pipsProposalTypes = []ProposalType{
+		ProposalTypeTechnicalPIPs,
+		ProposalTypeUpgradePIPs,
+		ProposalTypeCommunityPIPs,
+	}
+
+	networkProposalTypes = map[string][][]ProposalType{
+		"polkadot":    {offChainProposalTypes, openGovProposalTypes, gov1ProposalTypes},
+		"kusama":      {offChainProposalTypes, openGovProposalTypes, gov1ProposalTypes},
+		"westend":     {offChainProposalTypes, openGovProposalTypes},
+		"paseo":       {offChainProposalTypes, openGovProposalTypes},
+		"collectives": {offChainProposalTypes, {ProposalTypeFellowshipReferendum, ProposalTypeAllianceMotion, ProposalTypeAnnouncement}},
+		"polymesh":    {offChainProposalTypes, pipsProposalTypes, {ProposalTypeCommunityPIPs}},
+		"zeitgeist":   {offChainProposalTypes, gov1ProposalTypes, {ProposalTypeTreasuryProposal, ProposalTypeBounty, ProposalTypeAdvisoryCommittee}},
+	}
+)
+
+// Valid reports whether t is a known proposal type
+func (t ProposalType) Valid() bool {
+	for _, known := range AllProposalTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// PathSegment returns the API path segment for t
+func (t ProposalType) PathSegment() string {
+	return string(t)
+}
+
+// IsOffChain reports whether t is a purely off-chain post type
+func (t ProposalType) IsOffChain() bool {
+	return t == ProposalTypeDiscussion || t == ProposalTypeGrant
+}
+
+// IsOpenGov reports whether t is an OpenGov referendum type
+func (t ProposalType) IsOpenGov() bool {
+	for _, referendum := range openGovReferendumTypes {
+		if t == referendum {
+			return true
+		}
+	}
+	return false
+}
+
+func (t ProposalType) String() string {
+	return string(t)
+}
+
+// ParseProposalType converts a string into a known ProposalType
+func ParseProposalType(s string) (ProposalType, error) {
+	t := ProposalType(s)
+	if !t.Valid() {
+		return "", fmt.Errorf("invalid proposal type: %q", s)
+	}
+	return t, nil
+}
+
+// ProposalTypesForNetwork lists the proposal types available on network.
+// Unknown networks report every type.
+func ProposalTypesForNetwork(network string) []ProposalType {
+	groups, ok := networkProposalTypes[network]
+	if !ok {
+		return append([]ProposalType(nil), AllProposalTypes...)
+	}
+
+	seen := make(map[ProposalType]bool)
+	var types []ProposalType
+	for _, group := range groups {
+		for _, t := range group {
+			if !seen[t] {
+				seen[t] = true
+				types = append(types, t)
+			}
+		}
+	}
+	return types
+}
+
+// AvailableOn reports whether t is available on network
+func (t ProposalType) AvailableOn(network string) bool {
+	for _, available := range ProposalTypesForNetwork(network) {
+		if t == available {
+			return true
+		}
+	}
+	return false
+}
+
+// proposalPath validates t, substituting def when empty, and returns the API path segment
+func proposalPath(t ProposalType, def ProposalType) (string, error) {
+	if t == "" {
+		t = def
+	}
+	if !t.Valid() {
+		return "", fmt.Errorf("invalid proposal type: %q", t)
+	}
+	return t.PathSegment(), nil
+}
@@ -0,0 +1,45 @@
+package polkassembly
+
+import "testing"
+
+func TestProposalType(t *testing.T) {
+	if _, err := ParseProposalType("ReferendumV2"); err != nil {
+		t.Errorf("ReferendumV2 should be valid: %v", err)
+	}
+	if _, err := ParseProposalType("referendumv2"); err == nil {
+		t.Error("expected error for misspelled proposal type")
+	}
+
+	if _, err := testClient.GetPostByType(1, "Referendumv2"); err == nil {
+		t.Error("expected GetPostByType to reject invalid proposal type")
+	}
+
+	if !ProposalTypeFellowshipReferendum.AvailableOn("collectives") {
+		t.Error("fellowship referenda should be available on collectives")
+	}
+	if ProposalTypeTechnicalPIPs.AvailableOn("polkadot") {
+		t.Error("PIPs should not be available on polkadot")
+	}
+
+	unknown := ProposalTypesForNetwork("unknown")
+	if len(unknown) != len(AllProposalTypes) {
+		t.Fatalf("expected every type on an unknown network, got %d", len(unknown))
+	}
+	unknown[0] = "Changed"
+	if AllProposalTypes[0] == "Changed" {
+		t.Error("ProposalTypesForNetwork should not return AllProposalTypes itself")
+	}
+
+	for _, pt := range ProposalTypesForNetwork("westend") {
+		switch pt {
+		case ProposalTypeReferendumV2, ProposalTypeFellowshipReferendum:
+			if !pt.IsOpenGov() {
+				t.Errorf("%s should be an OpenGov referendum type", pt)
+			}
+		default:
+			if pt.IsOpenGov() {
+				t.Errorf("%s should not be an OpenGov referendum type", pt)
+			}
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package polkassembly
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/polkadot-go/polkassembly-api/scale"
+)
+
+// EnumValue is an enum argument for the encoder, or an enum decoded from a call
+type EnumValue = scale.EnumValue
+
+// DecodedCall is a runtime call decoded into a tree
+type DecodedCall = scale.DecodedCall
+
+// DecodedArg is a named argument or struct field of a DecodedCall
+type DecodedArg = scale.DecodedArg
+
+// ScaleCall converts the call into the form taken by the scale package,
+// turning typed arguments into values keyed by argument name
+func (c Call) ScaleCall() (scale.Call, error) {
+	args, err := callArgValues(c.Args)
+	if err != nil {
+		return scale.Call{}, fmt.Errorf("%s: %w", c, err)
+	}
+	return scale.Call{Pallet: c.Pallet, Method: c.Method, Args: args}, nil
+}
+
+// EncodeCall SCALE-encodes a call as described by m
+func EncodeCall(m *Metadata, call Call) ([]byte, error) {
+	sc, err := call.ScaleCall()
+	if err != nil {
+		return nil, err
+	}
+	return scale.EncodeCall(m, sc)
+}
+
+// Hex returns the SCALE-encoded call data as 0x-prefixed hex, as expected by
+// wallets and offline signers
+func (c Call) Hex(m *Metadata) (string, error) {
+	data, err := EncodeCall(m, c)
+	if err != nil {
+		return "", err
+	}
+	return "0x" + hex.EncodeToString(data), nil
+}
+
+// EncodeUnsignedExtrinsic wraps call data in a length-prefixed, unsigned v4 extrinsic
+func EncodeUnsignedExtrinsic(callData []byte) []byte {
+	return scale.EncodeUnsignedExtrinsic(callData)
+}
+
+// DecodeCall decodes SCALE-encoded call data as described by m
+func DecodeCall(m *Metadata, data []byte) (*DecodedCall, error) {
+	return scale.DecodeCall(m, data)
+}
+
+// DecodeCallHex decodes 0x-prefixed hex call data
+func DecodeCallHex(m *Metadata, s string) (*DecodedCall, error) {
+	return scale.DecodeCallHex(m, s)
+}
+
+// Metadata returns the metadata set in Config, or the built-in metadata of the network
+func (c *Client) Metadata() (*Metadata, error) {
+	if c.metadata != nil {
+		return c.metadata, nil
+	}
+	return DefaultMetadata(c.network)
+}
+
+// EncodeCall encodes call with the client's metadata and returns it as hex
+func (c *Client) EncodeCall(call Call) (string, error) {
+	m, err := c.Metadata()
+	if err != nil {
+		return "", err
+	}
+	return call.Hex(m)
+}
@@ -0,0 +1,392 @@
+package scale
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+// DecodedCall is a runtime call decoded into a tree. Argument values are
+// *big.Int for integers, bool, 0x-prefixed hex for bytes, SS58 strings for
+// accounts, nil for an empty Option, []interface{} for lists and tuples,
+// []DecodedArg for structs, EnumValue for enums and *DecodedCall for nested calls.
+type DecodedCall struct {
+	Pallet string       `json:"pallet"`
+	Method string       `json:"method"`
+	Args   []DecodedArg `json:"args"`
+}
+
+// DecodedArg is a named argument or struct field. Type is empty when the
+// call was not decoded from SCALE.
+type DecodedArg struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type,omitempty"`
+	Value interface{} `json:"value"`
+}
+
+func (d *DecodedCall) String() string {
+	return d.Pallet + "." + d.Method
+}
+
+// Arg returns the value of the named argument
+func (d *DecodedCall) Arg(name string) (interface{}, bool) {
+	for _, a := range d.Args {
+		if SameName(a.Name, name) {
+			return a.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Calls returns the calls nested directly in the arguments, e.g. the calls
+// of a batch or the call dispatched by dispatchAs or the whitelist
+func (d *DecodedCall) Calls() []*DecodedCall {
+	var calls []*DecodedCall
+	for _, a := range d.Args {
+		calls = append(calls, nestedCalls(a.Value)...)
+	}
+	return calls
+}
+
+// Walk visits d and every call nested in it, depth first
+func (d *DecodedCall) Walk(fn func(*DecodedCall)) {
+	fn(d)
+	for _, c := range d.Calls() {
+		c.Walk(fn)
+	}
+}
+
+func nestedCalls(v interface{}) []*DecodedCall {
+	switch x := v.(type) {
+	case *DecodedCall:
+		return []*DecodedCall{x}
+	case []interface{}:
+		var calls []*DecodedCall
+		for _, item := range x {
+			calls = append(calls, nestedCalls(item)...)
+		}
+		return calls
+	case []DecodedArg:
+		var calls []*DecodedCall
+		for _, a := range x {
+			calls = append(calls, nestedCalls(a.Value)...)
+		}
+		return calls
+	case EnumValue:
+		return nestedCalls(x.Value)
+	}
+	return nil
+}
+
+// DecodeCall decodes SCALE-encoded call data as described by m
+func DecodeCall(m *Metadata, data []byte) (*DecodedCall, error) {
+	d := &scaleDecoder{m: m, data: data}
+	call, err := d.call()
+	if err != nil {
+		return nil, err
+	}
+	if d.pos != len(d.data) {
+		return nil, fmt.Errorf("%d trailing bytes after %s", len(d.data)-d.pos, call)
+	}
+	return call, nil
+}
+
+// DecodeCallHex decodes 0x-prefixed hex call data
+func DecodeCallHex(m *Metadata, s string) (*DecodedCall, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(s), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid call data: %w", err)
+	}
+	return DecodeCall(m, data)
+}
+
+type scaleDecoder struct {
+	m    *Metadata
+	data []byte
+	pos  int
+}
+
+func (d *scaleDecoder) read(n int) ([]byte, error) {
+	if n < 0 || d.pos+n > len(d.data) {
+		return nil, fmt.Errorf("unexpected end of data at byte %d", d.pos)
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *scaleDecoder) byte() (byte, error) {
+	b, err := d.read(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (d *scaleDecoder) compact() (*big.Int, error) {
+	first, err := d.byte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch first & 0b11 {
+	case 0b00:
+		return big.NewInt(int64(first >> 2)), nil
+	case 0b01:
+		next, err := d.byte()
+		if err != nil {
+			return nil, err
+		}
+		return big.NewInt(int64(binary.LittleEndian.Uint16([]byte{first, next}) >> 2)), nil
+	case 0b10:
+		rest, err := d.read(3)
+		if err != nil {
+			return nil, err
+		}
+		return big.NewInt(int64(binary.LittleEndian.Uint32(append([]byte{first}, rest...)) >> 2)), nil
+	default:
+		b, err := d.read(int(first>>2) + 4)
+		if err != nil {
+			return nil, err
+		}
+		return fromLittleEndian(b), nil
+	}
+}
+
+func (d *scaleDecoder) length() (int, error) {
+	n, err := d.compact()
+	if err != nil {
+		return 0, err
+	}
+	if !n.IsInt64() || n.Int64() > int64(len(d.data)-d.pos) {
+		return 0, fmt.Errorf("invalid length %s at byte %d", n, d.pos)
+	}
+	return int(n.Int64()), nil
+}
+
+func (d *scaleDecoder) call() (*DecodedCall, error) {
+	b, err := d.read(2)
+	if err != nil {
+		return nil, err
+	}
+	p, cm, err := d.m.CallByIndex(b[0], b[1])
+	if err != nil {
+		return nil, err
+	}
+
+	pallet, method := CallName(p, cm)
+	call := &DecodedCall{Pallet: pallet, Method: method}
+	for _, f := range cm.Args {
+		v, err := d.value(f.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s: argument %s: %w", call, f.Name, err)
+		}
+		call.Args = append(call.Args, DecodedArg{Name: f.Name, Type: f.Type, Value: v})
+	}
+	return call, nil
+}
+
+func (d *scaleDecoder) value(typ string) (interface{}, error) {
+	typ = strings.TrimSpace(typ)
+
+	if inner, ok := genericArg(typ, "Box"); ok {
+		return d.value(inner)
+	}
+	if _, ok := genericArg(typ, "Compact"); ok {
+		return d.compact()
+	}
+	if inner, ok := genericArg(typ, "Option"); ok {
+		flag, err := d.byte()
+		if err != nil {
+			return nil, err
+		}
+		switch flag {
+		case 0:
+			return nil, nil
+		case 1:
+			return d.value(inner)
+		}
+		return nil, fmt.Errorf("invalid option flag %d", flag)
+	}
+	if inner, ok := genericArg(typ, "Vec"); ok {
+		if inner == "u8" {
+			return d.value("Bytes")
+		}
+		n, err := d.length()
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, 0, n)
+		for i := 0; i < n; i++ {
+			v, err := d.value(inner)
+			if err != nil {
+				return nil, fmt.Errorf("item %d: %w", i, err)
+			}
+			items = append(items, v)
+		}
+		return items, nil
+	}
+	if n, ok := byteArrayLen(typ); ok {
+		b, err := d.read(n)
+		if err != nil {
+			return nil, err
+		}
+		return "0x" + hex.EncodeToString(b), nil
+	}
+	if elems, ok := tupleElems(typ); ok {
+		items := make([]interface{}, 0, len(elems))
+		for _, elem := range elems {
+			v, err := d.value(elem)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+		}
+		return items, nil
+	}
+
+	switch typ {
+	case "bool":
+		b, err := d.byte()
+		if err != nil {
+			return nil, err
+		}
+		return b != 0, nil
+	case "u8", "u16", "u32", "u64", "u128", "u256", "i8", "i16", "i32", "i64", "i128":
+		return d.fixedInt(typ)
+	case "AccountId32", "AccountId":
+		b, err := d.read(32)
+		if err != nil {
+			return nil, err
+		}
+		return subkey.SS58Encode(b, d.m.SS58Prefix), nil
+	case "H256":
+		return d.value("[u8; 32]")
+	case "Bytes":
+		n, err := d.length()
+		if err != nil {
+			return nil, err
+		}
+		b, err := d.read(n)
+		if err != nil {
+			return nil, err
+		}
+		return "0x" + hex.EncodeToString(b), nil
+	case "RuntimeCall", "Call":
+		return d.call()
+	}
+
+	def, ok := d.m.Types[typ]
+	if !ok {
+		return nil, fmt.Errorf("unknown type: %s", typ)
+	}
+	switch {
+	case def.Alias != "":
+		return d.value(def.Alias)
+	case len(def.Variants) > 0:
+		return d.enum(typ, def.Variants)
+	default:
+		return d.fields(def.Fields)
+	}
+}
+
+func (d *scaleDecoder) fields(defs []FieldMetadata) ([]DecodedArg, error) {
+	fields := make([]DecodedArg, 0, len(defs))
+	for _, f := range defs {
+		v, err := d.value(f.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.Name, err)
+		}
+		fields = append(fields, DecodedArg{Name: f.Name, Type: f.Type, Value: v})
+	}
+	return fields, nil
+}
+
+func (d *scaleDecoder) enum(typ string, variants []VariantMetadata) (interface{}, error) {
+	index, err := d.byte()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, variant := range variants {
+		if variant.Index != index {
+			continue
+		}
+
+		ev := EnumValue{Variant: variant.Name}
+		switch {
+		case len(variant.Fields) == 0:
+		case variant.Fields[0].Name != "":
+			ev.Value, err = d.fields(variant.Fields)
+		case len(variant.Fields) == 1:
+			ev.Value, err = d.value(variant.Fields[0].Type)
+		default:
+			items := make([]interface{}, 0, len(variant.Fields))
+			for _, f := range variant.Fields {
+				var v interface{}
+				if v, err = d.value(f.Type); err != nil {
+					break
+				}
+				items = append(items, v)
+			}
+			ev.Value = items
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s::%s: %w", typ, variant.Name, err)
+		}
+		return ev, nil
+	}
+	return nil, fmt.Errorf("unknown variant index %d of %s", index, typ)
+}
+
+func (d *scaleDecoder) fixedInt(typ string) (*big.Int, error) {
+	var bits int
+	fmt.Sscanf(typ[1:], "%d", &bits)
+	b, err := d.read(bits / 8)
+	if err != nil {
+		return nil, err
+	}
+
+	n := fromLittleEndian(b)
+	if typ[0] == 'i' && b[len(b)-1]&0x80 != 0 {
+		n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(bits)))
+	}
+	return n, nil
+}
+
+func fromLittleEndian(b []byte) *big.Int {
+	be := make([]byte, len(b))
+	for i, x := range b {
+		be[len(b)-1-i] = x
+	}
+	return new(big.Int).SetBytes(be)
+}
+
+// CallName returns the pallet and method names of a call in the form used by
+// Call and DecodedCall, e.g. ConvictionVoting.remove_vote -> convictionVoting.removeVote
+func CallName(p *PalletMetadata, c *CallMetadata) (pallet, method string) {
+	return lowerFirst(p.Name), snakeToCamel(c.Name)
+}
+
+// lowerFirst turns a pallet name into the form used by Call, e.g. ConvictionVoting -> convictionVoting
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// snakeToCamel turns a call name into the form used by Call, e.g. batch_all -> batchAll
+func snakeToCamel(s string) string {
+	parts := strings.Split(s, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,540 @@
+package scale
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+// EnumValue is an enum argument for the encoder. Value is nil for unit
+// variants, the field value for a single unnamed field, a []interface{} for
+// several unnamed fields, or a map[string]interface{} for named fields.
+// Unit variants can also be given by name as a string or by index as an integer.
+type EnumValue struct {
+	Variant string      `json:"variant"`
+	Value   interface{} `json:"value,omitempty"`
+}
+
+// Call is a runtime call to encode. Args are keyed by argument name, which
+// matches the metadata case-insensitively and ignoring underscores.
+type Call struct {
+	Pallet string                 `json:"pallet"`
+	Method string                 `json:"method"`
+	Args   map[string]interface{} `json:"args"`
+}
+
+func (c Call) String() string {
+	return c.Pallet + "." + c.Method
+}
+
+// CallValue is implemented by call types that convert to a Call, so they can
+// be nested in the arguments of another call, e.g. the calls of a batch
+type CallValue interface {
+	ScaleCall() (Call, error)
+}
+
+// EncodeCall SCALE-encodes a call as described by m
+func EncodeCall(m *Metadata, call Call) ([]byte, error) {
+	e := &scaleEncoder{m: m}
+	if err := e.call(call); err != nil {
+		return nil, err
+	}
+	return e.buf.Bytes(), nil
+}
+
+// EncodeUnsignedExtrinsic wraps call data in a length-prefixed, unsigned v4 extrinsic
+func EncodeUnsignedExtrinsic(callData []byte) []byte {
+	body := append([]byte{0x04}, callData...)
+	return append(CompactLength(len(body)), body...)
+}
+
+// CompactLength SCALE-encodes a collection length
+func CompactLength(n int) []byte {
+	v := uint64(n)
+	switch {
+	case v < 1<<6:
+		return []byte{byte(v << 2)}
+	case v < 1<<14:
+		b := make([]byte, 2)
+		binary.LittleEndian.PutUint16(b, uint16(v<<2|0b01))
+		return b
+	default:
+		b := make([]byte, 4)
+		binary.LittleEndian.PutUint32(b, uint32(v<<2|0b10))
+		return b
+	}
+}
+
+// encodeCompact SCALE-encodes a non-negative integer in compact form
+func encodeCompact(n *big.Int) ([]byte, error) {
+	if n.Sign() < 0 {
+		return nil, fmt.Errorf("compact value is negative: %s", n)
+	}
+	if n.IsUint64() && n.Uint64() < 1<<30 {
+		return CompactLength(int(n.Uint64())), nil
+	}
+
+	le := littleEndian(n)
+	if len(le) > 67 {
+		return nil, fmt.Errorf("compact value too large: %s", n)
+	}
+	if len(le) < 4 {
+		le = append(le, make([]byte, 4-len(le))...)
+	}
+	return append([]byte{byte((len(le)-4)<<2 | 0b11)}, le...), nil
+}
+
+// littleEndian returns the minimal little-endian bytes of a non-negative n
+func littleEndian(n *big.Int) []byte {
+	be := n.Bytes()
+	le := make([]byte, len(be))
+	for i, b := range be {
+		le[len(be)-1-i] = b
+	}
+	return le
+}
+
+type scaleEncoder struct {
+	m   *Metadata
+	buf bytes.Buffer
+}
+
+func (e *scaleEncoder) call(c Call) error {
+	p, cm, err := e.m.Call(c.Pallet, c.Method)
+	if err != nil {
+		return err
+	}
+	e.buf.WriteByte(p.Index)
+	e.buf.WriteByte(cm.Index)
+	for _, f := range cm.Args {
+		v, ok := LookupField(c.Args, f.Name)
+		if !ok {
+			return fmt.Errorf("%s: missing argument %s", c, f.Name)
+		}
+		if err := e.value(f.Type, v); err != nil {
+			return fmt.Errorf("%s: argument %s: %w", c, f.Name, err)
+		}
+	}
+	return nil
+}
+
+func (e *scaleEncoder) value(typ string, v interface{}) error {
+	typ = strings.TrimSpace(typ)
+
+	if inner, ok := genericArg(typ, "Box"); ok {
+		return e.value(inner, v)
+	}
+	if _, ok := genericArg(typ, "Compact"); ok {
+		n, err := toBigInt(v)
+		if err != nil {
+			return err
+		}
+		b, err := encodeCompact(n)
+		if err != nil {
+			return err
+		}
+		e.buf.Write(b)
+		return nil
+	}
+	if inner, ok := genericArg(typ, "Option"); ok {
+		if isNil(v) {
+			e.buf.WriteByte(0)
+			return nil
+		}
+		e.buf.WriteByte(1)
+		return e.value(inner, reflect.Indirect(reflect.ValueOf(v)).Interface())
+	}
+	if inner, ok := genericArg(typ, "Vec"); ok {
+		if inner == "u8" {
+			return e.value("Bytes", v)
+		}
+		items, err := toSlice(v)
+		if err != nil {
+			return err
+		}
+		e.buf.Write(CompactLength(len(items)))
+		for i, item := range items {
+			if err := e.value(inner, item); err != nil {
+				return fmt.Errorf("item %d: %w", i, err)
+			}
+		}
+		return nil
+	}
+	if n, ok := byteArrayLen(typ); ok {
+		b, err := toBytes(v)
+		if err != nil {
+			return err
+		}
+		if len(b) != n {
+			return fmt.Errorf("expected %d bytes, got %d", n, len(b))
+		}
+		e.buf.Write(b)
+		return nil
+	}
+	if elems, ok := tupleElems(typ); ok {
+		items, err := toSlice(v)
+		if err != nil {
+			return err
+		}
+		if len(items) != len(elems) {
+			return fmt.Errorf("expected %d tuple elements, got %d", len(elems), len(items))
+		}
+		for i, elem := range elems {
+			if err := e.value(elem, items[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch typ {
+	case "bool":
+		b, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("expected bool, got %T", v)
+		}
+		if b {
+			e.buf.WriteByte(1)
+		} else {
+			e.buf.WriteByte(0)
+		}
+		return nil
+	case "u8", "u16", "u32", "u64", "u128", "u256", "i8", "i16", "i32", "i64", "i128":
+		n, err := toBigInt(v)
+		if err != nil {
+			return err
+		}
+		b, err := encodeFixedInt(n, typ)
+		if err != nil {
+			return err
+		}
+		e.buf.Write(b)
+		return nil
+	case "AccountId32", "AccountId":
+		id, err := toAccountID(v)
+		if err != nil {
+			return err
+		}
+		e.buf.Write(id)
+		return nil
+	case "H256":
+		return e.value("[u8; 32]", v)
+	case "Bytes":
+		b, err := toBytes(v)
+		if err != nil {
+			return err
+		}
+		e.buf.Write(CompactLength(len(b)))
+		e.buf.Write(b)
+		return nil
+	case "RuntimeCall", "Call":
+		switch c := v.(type) {
+		case Call:
+			return e.call(c)
+		case *Call:
+			return e.call(*c)
+		case *DecodedCall:
+			args, _ := fieldValues(c.Args)
+			return e.call(Call{Pallet: c.Pallet, Method: c.Method, Args: args})
+		case CallValue:
+			call, err := c.ScaleCall()
+			if err != nil {
+				return err
+			}
+			return e.call(call)
+		}
+		return fmt.Errorf("expected Call, got %T", v)
+	}
+
+	def, ok := e.m.Types[typ]
+	if !ok {
+		return fmt.Errorf("unknown type: %s", typ)
+	}
+	switch {
+	case def.Alias != "":
+		return e.value(def.Alias, v)
+	case len(def.Variants) > 0:
+		return e.enum(typ, def.Variants, v)
+	default:
+		fields, ok := fieldValues(v)
+		if !ok {
+			return fmt.Errorf("expected fields of %s, got %T", typ, v)
+		}
+		return e.fields(def.Fields, fields)
+	}
+}
+
+func (e *scaleEncoder) fields(defs []FieldMetadata, values map[string]interface{}) error {
+	for _, f := range defs {
+		v, ok := LookupField(values, f.Name)
+		if !ok {
+			return fmt.Errorf("missing field %s", f.Name)
+		}
+		if err := e.value(f.Type, v); err != nil {
+			return fmt.Errorf("field %s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+func (e *scaleEncoder) enum(typ string, variants []VariantMetadata, v interface{}) error {
+	var ev EnumValue
+	switch x := v.(type) {
+	case EnumValue:
+		ev = x
+	case *EnumValue:
+		ev = *x
+	case string:
+		ev.Variant = x
+	default:
+		index, err := toBigInt(v)
+		if err != nil || !index.IsUint64() {
+			return fmt.Errorf("expected variant of %s, got %T", typ, v)
+		}
+		for _, variant := range variants {
+			if uint64(variant.Index) == index.Uint64() {
+				ev.Variant = variant.Name
+			}
+		}
+	}
+
+	for _, variant := range variants {
+		if !SameName(variant.Name, ev.Variant) {
+			continue
+		}
+		e.buf.WriteByte(variant.Index)
+
+		switch {
+		case len(variant.Fields) == 0:
+			return nil
+		case variant.Fields[0].Name != "":
+			fields, ok := fieldValues(ev.Value)
+			if !ok {
+				return fmt.Errorf("expected fields of %s::%s, got %T", typ, variant.Name, ev.Value)
+			}
+			return e.fields(variant.Fields, fields)
+		case len(variant.Fields) == 1:
+			return e.value(variant.Fields[0].Type, ev.Value)
+		default:
+			items, err := toSlice(ev.Value)
+			if err != nil {
+				return err
+			}
+			if len(items) != len(variant.Fields) {
+				return fmt.Errorf("expected %d fields for %s::%s, got %d", len(variant.Fields), typ, variant.Name, len(items))
+			}
+			for i, f := range variant.Fields {
+				if err := e.value(f.Type, items[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown variant of %s: %q", typ, ev.Variant)
+}
+
+// encodeFixedInt encodes n as a little-endian integer of type typ (u8-u256, i8-i128)
+func encodeFixedInt(n *big.Int, typ string) ([]byte, error) {
+	bits, err := strconv.Atoi(typ[1:])
+	if err != nil {
+		return nil, fmt.Errorf("unknown integer type: %s", typ)
+	}
+	size := bits / 8
+
+	v := new(big.Int).Set(n)
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	if typ[0] == 'i' {
+		half := new(big.Int).Rsh(limit, 1)
+		if v.Cmp(half) >= 0 || v.Cmp(new(big.Int).Neg(half)) < 0 {
+			return nil, fmt.Errorf("%s overflows %s", n, typ)
+		}
+		if v.Sign() < 0 {
+			v.Add(v, limit)
+		}
+	} else if v.Sign() < 0 || v.Cmp(limit) >= 0 {
+		return nil, fmt.Errorf("%s overflows %s", n, typ)
+	}
+
+	le := littleEndian(v)
+	return append(le, make([]byte, size-len(le))...), nil
+}
+
+// genericArg returns T when typ is name<T>
+func genericArg(typ, name string) (string, bool) {
+	if strings.HasPrefix(typ, name+"<") && strings.HasSuffix(typ, ">") {
+		return strings.TrimSpace(typ[len(name)+1 : len(typ)-1]), true
+	}
+	return "", false
+}
+
+// byteArrayLen returns N when typ is [u8; N]
+func byteArrayLen(typ string) (int, bool) {
+	if !strings.HasPrefix(typ, "[u8;") || !strings.HasSuffix(typ, "]") {
+		return 0, false
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(typ[4 : len(typ)-1]))
+	return n, err == nil
+}
+
+// tupleElems returns the element types when typ is a tuple (A, B, ...)
+func tupleElems(typ string) ([]string, bool) {
+	if !strings.HasPrefix(typ, "(") || !strings.HasSuffix(typ, ")") {
+		return nil, false
+	}
+
+	inner := typ[1 : len(typ)-1]
+	var elems []string
+	depth, start := 0, 0
+	for i, r := range inner {
+		switch r {
+		case '<', '(', '[':
+			depth++
+		case '>', ')', ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				elems = append(elems, strings.TrimSpace(inner[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if last := strings.TrimSpace(inner[start:]); last != "" {
+		elems = append(elems, last)
+	}
+	return elems, true
+}
+
+// fieldValues accepts struct values as a map or as decoded fields
+func fieldValues(v interface{}) (map[string]interface{}, bool) {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		return x, true
+	case []DecodedArg:
+		values := make(map[string]interface{}, len(x))
+		for _, f := range x {
+			values[f.Name] = f.Value
+		}
+		return values, true
+	}
+	return nil, false
+}
+
+// LookupField finds a value by name, matching names as SameName does
+func LookupField(values map[string]interface{}, name string) (interface{}, bool) {
+	if v, ok := values[name]; ok {
+		return v, true
+	}
+	for k, v := range values {
+		if SameName(k, name) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Ptr && rv.IsNil()
+}
+
+func toBigInt(v interface{}) (*big.Int, error) {
+	switch x := v.(type) {
+	case *big.Int:
+		return x, nil
+	case big.Int:
+		return &x, nil
+	case string:
+		return parseInt(x)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(rv.Uint()), nil
+	}
+	return nil, fmt.Errorf("expected integer, got %T", v)
+}
+
+// parseInt parses a decimal or 0x-prefixed hex integer, as balances are
+// returned by the API
+func parseInt(s string) (*big.Int, error) {
+	s = strings.TrimSpace(s)
+	v := new(big.Int)
+	var ok bool
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		_, ok = v.SetString(s[2:], 16)
+	} else {
+		_, ok = v.SetString(s, 10)
+	}
+	if !ok {
+		return nil, fmt.Errorf("invalid integer: %q", s)
+	}
+	return v, nil
+}
+
+func toBytes(v interface{}) ([]byte, error) {
+	switch x := v.(type) {
+	case []byte:
+		return x, nil
+	case string:
+		if !strings.HasPrefix(x, "0x") {
+			return []byte(x), nil
+		}
+		b, err := hex.DecodeString(x[2:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex: %q", x)
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("expected bytes, got %T", v)
+}
+
+// toAccountID accepts an SS58 address, 0x-prefixed hex or raw bytes
+func toAccountID(v interface{}) ([]byte, error) {
+	if s, ok := v.(string); ok && !strings.HasPrefix(s, "0x") {
+		_, id, err := subkey.SS58Decode(s)
+		if err != nil {
+			return nil, fmt.Errorf("decode address %s: %w", s, err)
+		}
+		if len(id) != 32 {
+			return nil, fmt.Errorf("unexpected account id length for %s: %d", s, len(id))
+		}
+		return id, nil
+	}
+	b, err := toBytes(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != 32 {
+		return nil, fmt.Errorf("expected 32-byte account id, got %d bytes", len(b))
+	}
+	return b, nil
+}
+
+func toSlice(v interface{}) ([]interface{}, error) {
+	if items, ok := v.([]interface{}); ok {
+		return items, nil
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected list, got %T", v)
+	}
+	items := make([]interface{}, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+	return items, nil
+}
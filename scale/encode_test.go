@@ -0,0 +1,28 @@
+package scale
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+func TestEncodeCompact(t *testing.T) {
+	tests := map[int64]string{
+		0:       "00",
+		1:       "04",
+		63:      "fc",
+		64:      "0101",
+		16383:   "fdff",
+		16384:   "02000100",
+		1 << 30: "0300000040",
+	}
+	for n, want := range tests {
+		got, err := encodeCompact(big.NewInt(n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(got) != want {
+			t.Errorf("compact(%d) = %x, want %s", n, got, want)
+		}
+	}
+}
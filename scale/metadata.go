@@ -0,0 +1,148 @@
+// Package scale encodes and decodes SCALE runtime calls as described by a
+// Metadata, without depending on the Polkassembly client.
+package scale
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Metadata describes the runtime calls the SCALE encoder and decoder know
+// about: pallet and call indices, argument types and named type definitions.
+// It is this package's own JSON format, not the runtime metadata itself, so it
+// can be kept in a local file and edited by hand. ParseRuntimeMetadata
+// converts V14 and V15 runtime metadata into it, and cmd/generate_metadata
+// writes it to a file from a node after a runtime upgrade.
+//
+// Argument types are written Rust-style: primitives (bool, u8-u128, i8-i128,
+// AccountId32, H256, Bytes), Compact<T>, Vec<T>, Option<T>, Box<T>, [u8; N],
+// tuples (A, B), RuntimeCall, or a name defined in Types.
+type Metadata struct {
+	SpecVersion int `json:"specVersion,omitempty"`
+	// SS58Prefix formats decoded account ids
+	SS58Prefix uint16             `json:"ss58Prefix"`
+	Pallets    []PalletMetadata   `json:"pallets"`
+	Types      map[string]TypeDef `json:"types"`
+}
+
+type PalletMetadata struct {
+	Name  string         `json:"name"`
+	Index uint8          `json:"index"`
+	Calls []CallMetadata `json:"calls"`
+}
+
+type CallMetadata struct {
+	Name  string          `json:"name"`
+	Index uint8           `json:"index"`
+	Args  []FieldMetadata `json:"args"`
+}
+
+// FieldMetadata is a named, typed call argument or struct field. Unnamed
+// fields are allowed in enum variants.
+type FieldMetadata struct {
+	Name string `json:"name,omitempty"`
+	Type string `json:"type"`
+}
+
+type VariantMetadata struct {
+	Name   string          `json:"name"`
+	Index  uint8           `json:"index"`
+	Fields []FieldMetadata `json:"fields,omitempty"`
+}
+
+// TypeDef is an alias (a JSON string), a struct ({"fields": [...]}) or an
+// enum ({"variants": [...]})
+type TypeDef struct {
+	Alias    string            `json:"-"`
+	Fields   []FieldMetadata   `json:"fields,omitempty"`
+	Variants []VariantMetadata `json:"variants,omitempty"`
+}
+
+func (t *TypeDef) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &t.Alias); err == nil {
+		return nil
+	}
+	type plain TypeDef
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+func (t TypeDef) MarshalJSON() ([]byte, error) {
+	if t.Alias != "" {
+		return json.Marshal(t.Alias)
+	}
+	type plain TypeDef
+	return json.Marshal(plain(t))
+}
+
+// LoadMetadata reads metadata from a JSON file
+func LoadMetadata(path string) (*Metadata, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read metadata: %w", err)
+	}
+	return ParseMetadata(data)
+}
+
+// ParseMetadata parses JSON metadata
+func ParseMetadata(data []byte) (*Metadata, error) {
+	var m Metadata
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parse metadata: %w", err)
+	}
+	if len(m.Pallets) == 0 {
+		return nil, fmt.Errorf("parse metadata: no pallets")
+	}
+	return &m, nil
+}
+
+// Pallet finds a pallet by name. Names match case-insensitively and ignoring
+// underscores, so "convictionVoting" finds "ConvictionVoting".
+func (m *Metadata) Pallet(name string) (*PalletMetadata, error) {
+	for i := range m.Pallets {
+		if SameName(m.Pallets[i].Name, name) {
+			return &m.Pallets[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown pallet: %s", name)
+}
+
+// Call finds a call by pallet and method name
+func (m *Metadata) Call(pallet, method string) (*PalletMetadata, *CallMetadata, error) {
+	p, err := m.Pallet(pallet)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range p.Calls {
+		if SameName(p.Calls[i].Name, method) {
+			return p, &p.Calls[i], nil
+		}
+	}
+	return nil, nil, fmt.Errorf("unknown call: %s.%s", pallet, method)
+}
+
+// CallByIndex finds a call by its pallet and call index
+func (m *Metadata) CallByIndex(pallet, call uint8) (*PalletMetadata, *CallMetadata, error) {
+	for i := range m.Pallets {
+		p := &m.Pallets[i]
+		if p.Index != pallet {
+			continue
+		}
+		for j := range p.Calls {
+			if p.Calls[j].Index == call {
+				return p, &p.Calls[j], nil
+			}
+		}
+		return nil, nil, fmt.Errorf("unknown call index %d in pallet %s", call, p.Name)
+	}
+	return nil, nil, fmt.Errorf("unknown pallet index: %d", pallet)
+}
+
+// sameName compares pallet, call and field names across naming conventions
+func SameName(a, b string) bool {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.ReplaceAll(s, "_", ""))
+	}
+	return normalize(a) == normalize(b)
+}
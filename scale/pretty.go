@@ -0,0 +1,117 @@
+package scale
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+)
+
+// Pretty renders the call tree as indented text, e.g.
+//
+//	utility.batchAll
+//	  calls:
+//	    - treasury.spendLocal
+//	        amount: 1000
+//	        beneficiary: Id(15oF4u...)
+func (d *DecodedCall) Pretty() string {
+	var b strings.Builder
+	writeCall(&b, d, 0)
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func writeCall(b *strings.Builder, d *DecodedCall, indent int) {
+	b.WriteString(d.String())
+	b.WriteString("\n")
+	writeFields(b, d.Args, indent+1)
+}
+
+func writeFields(b *strings.Builder, fields []DecodedArg, indent int) {
+	for _, f := range fields {
+		b.WriteString(strings.Repeat("  ", indent))
+		b.WriteString(f.Name)
+		b.WriteString(":")
+		writeValue(b, f.Value, indent)
+	}
+}
+
+// writeValue writes v after a label, inline when it is a scalar
+func writeValue(b *strings.Builder, v interface{}, indent int) {
+	if s, ok := scalarString(v); ok {
+		b.WriteString(" ")
+		b.WriteString(s)
+		b.WriteString("\n")
+		return
+	}
+
+	switch x := v.(type) {
+	case *DecodedCall:
+		b.WriteString(" ")
+		writeCall(b, x, indent)
+	case []DecodedArg:
+		b.WriteString("\n")
+		writeFields(b, x, indent+1)
+	case EnumValue:
+		b.WriteString(" ")
+		b.WriteString(x.Variant)
+		writeValue(b, x.Value, indent)
+	case []interface{}:
+		b.WriteString("\n")
+		for _, item := range x {
+			b.WriteString(strings.Repeat("  ", indent+1))
+			b.WriteString("-")
+			writeValue(b, item, indent+1)
+		}
+	default:
+		b.WriteString(fmt.Sprintf(" %v\n", v))
+	}
+}
+
+// scalarString formats values that fit on one line
+func scalarString(v interface{}) (string, bool) {
+	switch x := v.(type) {
+	case nil:
+		return "None", true
+	case *big.Int:
+		return x.String(), true
+	case string:
+		return readableHex(x), true
+	case bool, float64, int:
+		return fmt.Sprint(x), true
+	case []interface{}:
+		if len(x) == 0 {
+			return "[]", true
+		}
+	case []DecodedArg:
+		if len(x) == 0 {
+			return "{}", true
+		}
+	case EnumValue:
+		if x.Value == nil {
+			return x.Variant, true
+		}
+		if s, ok := scalarString(x.Value); ok {
+			return x.Variant + "(" + s + ")", true
+		}
+	}
+	return "", false
+}
+
+// readableHex shows hex-encoded bytes as text when they are printable UTF-8,
+// as is common for remarks and bounty descriptions
+func readableHex(s string) string {
+	if !strings.HasPrefix(s, "0x") || len(s) <= 2 {
+		return s
+	}
+	b, err := hex.DecodeString(s[2:])
+	if err != nil || !utf8.Valid(b) {
+		return s
+	}
+	for _, r := range string(b) {
+		if r < 0x20 && r != '\n' && r != '\t' {
+			return s
+		}
+	}
+	return fmt.Sprintf("%q", string(b))
+}
@@ -0,0 +1,684 @@
+package scale
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// runtimeMetadataMagic prefixes SCALE-encoded runtime metadata ("meta")
+var runtimeMetadataMagic = []byte("meta")
+
+// Kinds of type definition in the portable type registry
+const (
+	typeDefComposite = iota
+	typeDefVariant
+	typeDefSequence
+	typeDefArray
+	typeDefTuple
+	typeDefPrimitive
+	typeDefCompact
+	typeDefBitSequence
+)
+
+// primitiveNames are the primitive types of the registry by index
+var primitiveNames = []string{
+	"bool", "char", "str", "u8", "u16", "u32", "u64", "u128", "u256",
+	"i8", "i16", "i32", "i64", "i128", "i256",
+}
+
+// reservedTypeNames are handled by the encoder and decoder themselves, so
+// registry types cannot take them
+var reservedTypeNames = map[string]bool{
+	"Box": true, "Compact": true, "Option": true, "Vec": true, "Bytes": true,
+	"AccountId": true, "AccountId32": true, "H256": true, "RuntimeCall": true, "Call": true,
+}
+
+// ParseRuntimeMetadata converts the SCALE-encoded runtime metadata returned by
+// the state_getMetadata RPC into Metadata. Versions 14 and 15 are supported.
+// Every call of every pallet is kept along with the types its arguments use;
+// the SS58 prefix and spec version are read from the System constants.
+func ParseRuntimeMetadata(data []byte) (*Metadata, error) {
+	if !bytes.HasPrefix(data, runtimeMetadataMagic) {
+		// The Metadata_metadata_at_version runtime API adds a length prefix
+		d := &scaleDecoder{data: data}
+		if n, err := d.length(); err != nil || n != len(data)-d.pos || !bytes.HasPrefix(data[d.pos:], runtimeMetadataMagic) {
+			return nil, fmt.Errorf("parse runtime metadata: missing magic number")
+		}
+		data = data[d.pos:]
+	}
+
+	d := &scaleDecoder{data: data, pos: len(runtimeMetadataMagic)}
+	version, err := d.byte()
+	if err != nil {
+		return nil, fmt.Errorf("parse runtime metadata: %w", err)
+	}
+	if version != 14 && version != 15 {
+		return nil, fmt.Errorf("parse runtime metadata: unsupported version %d", version)
+	}
+
+	types, err := readPortableTypes(d)
+	if err != nil {
+		return nil, fmt.Errorf("parse runtime metadata: types: %w", err)
+	}
+	pallets, err := readRuntimePallets(d, version)
+	if err != nil {
+		return nil, fmt.Errorf("parse runtime metadata: pallets: %w", err)
+	}
+
+	r := &runtimeTypes{
+		types: types,
+		names: make(map[int]string),
+		taken: make(map[string]int),
+		defs:  make(map[string]TypeDef),
+	}
+	m := &Metadata{}
+	for _, p := range pallets {
+		if p.name == "System" {
+			if err := m.readSystemConstants(p.constants); err != nil {
+				return nil, fmt.Errorf("parse runtime metadata: %w", err)
+			}
+		}
+		if p.calls == nil {
+			continue
+		}
+
+		t, ok := types[*p.calls]
+		if !ok || t.kind != typeDefVariant {
+			return nil, fmt.Errorf("parse runtime metadata: calls of %s are not an enum", p.name)
+		}
+		pallet := PalletMetadata{Name: p.name, Index: p.index}
+		for _, v := range t.variants {
+			args, err := r.fields(v.fields)
+			if err != nil {
+				return nil, fmt.Errorf("parse runtime metadata: %s.%s: %w", p.name, v.name, err)
+			}
+			pallet.Calls = append(pallet.Calls, CallMetadata{Name: v.name, Index: v.index, Args: args})
+		}
+		m.Pallets = append(m.Pallets, pallet)
+	}
+	if len(m.Pallets) == 0 {
+		return nil, fmt.Errorf("parse runtime metadata: no pallets with calls")
+	}
+
+	m.Types = r.defs
+	return m, nil
+}
+
+// ParseRuntimeMetadataHex parses the 0x-prefixed hex returned by state_getMetadata
+func ParseRuntimeMetadataHex(s string) (*Metadata, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(s), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid runtime metadata: %w", err)
+	}
+	return ParseRuntimeMetadata(data)
+}
+
+// readSystemConstants fills the SS58 prefix and spec version
+func (m *Metadata) readSystemConstants(constants []runtimeConstant) error {
+	for _, c := range constants {
+		switch c.name {
+		case "SS58Prefix":
+			if len(c.value) != 2 {
+				return fmt.Errorf("invalid SS58Prefix constant")
+			}
+			m.SS58Prefix = binary.LittleEndian.Uint16(c.value)
+		case "Version":
+			// RuntimeVersion starts with spec_name, impl_name, authoring_version and spec_version
+			d := &scaleDecoder{data: c.value}
+			if _, err := d.text(); err != nil {
+				return fmt.Errorf("invalid Version constant: %w", err)
+			}
+			if _, err := d.text(); err != nil {
+				return fmt.Errorf("invalid Version constant: %w", err)
+			}
+			b, err := d.read(8)
+			if err != nil {
+				return fmt.Errorf("invalid Version constant: %w", err)
+			}
+			m.SpecVersion = int(binary.LittleEndian.Uint32(b[4:]))
+		}
+	}
+	return nil
+}
+
+// portableType is an entry of the portable type registry
+type portableType struct {
+	path      []string
+	params    []*int
+	kind      int
+	fields    []portableField   // composite
+	variants  []portableVariant // variant
+	elem      int               // sequence, array, compact
+	length    int               // array
+	tuple     []int
+	primitive int
+}
+
+type portableField struct {
+	name string
+	ty   int
+}
+
+type portableVariant struct {
+	name   string
+	index  uint8
+	fields []portableField
+}
+
+type runtimePallet struct {
+	name      string
+	calls     *int
+	constants []runtimeConstant
+	index     uint8
+}
+
+type runtimeConstant struct {
+	name  string
+	value []byte
+}
+
+func readPortableTypes(d *scaleDecoder) (map[int]portableType, error) {
+	n, err := d.length()
+	if err != nil {
+		return nil, err
+	}
+
+	types := make(map[int]portableType, n)
+	for i := 0; i < n; i++ {
+		id, err := d.typeID()
+		if err != nil {
+			return nil, err
+		}
+		t, err := readPortableType(d)
+		if err != nil {
+			return nil, fmt.Errorf("type %d: %w", id, err)
+		}
+		types[id] = t
+	}
+	return types, nil
+}
+
+func readPortableType(d *scaleDecoder) (portableType, error) {
+	var t portableType
+	var err error
+	if t.path, err = d.texts(); err != nil {
+		return t, err
+	}
+
+	params, err := d.length()
+	if err != nil {
+		return t, err
+	}
+	for i := 0; i < params; i++ {
+		if _, err := d.text(); err != nil {
+			return t, err
+		}
+		param, err := d.optionalTypeID()
+		if err != nil {
+			return t, err
+		}
+		t.params = append(t.params, param)
+	}
+
+	kind, err := d.byte()
+	if err != nil {
+		return t, err
+	}
+	t.kind = int(kind)
+	switch t.kind {
+	case typeDefComposite:
+		t.fields, err = d.portableFields()
+	case typeDefVariant:
+		var n int
+		if n, err = d.length(); err != nil {
+			return t, err
+		}
+		for i := 0; i < n && err == nil; i++ {
+			var v portableVariant
+			if v.name, err = d.text(); err != nil {
+				break
+			}
+			if v.fields, err = d.portableFields(); err != nil {
+				break
+			}
+			if v.index, err = d.byte(); err != nil {
+				break
+			}
+			_, err = d.texts()
+			t.variants = append(t.variants, v)
+		}
+	case typeDefSequence, typeDefCompact:
+		t.elem, err = d.typeID()
+	case typeDefArray:
+		var b []byte
+		if b, err = d.read(4); err != nil {
+			return t, err
+		}
+		t.length = int(binary.LittleEndian.Uint32(b))
+		t.elem, err = d.typeID()
+	case typeDefTuple:
+		var n int
+		if n, err = d.length(); err != nil {
+			return t, err
+		}
+		for i := 0; i < n && err == nil; i++ {
+			var elem int
+			elem, err = d.typeID()
+			t.tuple = append(t.tuple, elem)
+		}
+	case typeDefPrimitive:
+		var p byte
+		p, err = d.byte()
+		t.primitive = int(p)
+	case typeDefBitSequence:
+		if _, err = d.typeID(); err == nil {
+			_, err = d.typeID()
+		}
+	default:
+		return t, fmt.Errorf("unknown type definition %d", kind)
+	}
+	if err != nil {
+		return t, err
+	}
+
+	_, err = d.texts()
+	return t, err
+}
+
+func readRuntimePallets(d *scaleDecoder, version byte) ([]runtimePallet, error) {
+	n, err := d.length()
+	if err != nil {
+		return nil, err
+	}
+
+	pallets := make([]runtimePallet, 0, n)
+	for i := 0; i < n; i++ {
+		var p runtimePallet
+		if p.name, err = d.text(); err != nil {
+			return nil, err
+		}
+		if err := d.skipStorage(); err != nil {
+			return nil, fmt.Errorf("%s storage: %w", p.name, err)
+		}
+		if p.calls, err = d.optionalTypeID(); err != nil {
+			return nil, err
+		}
+		if _, err = d.optionalTypeID(); err != nil { // events
+			return nil, err
+		}
+
+		constants, err := d.length()
+		if err != nil {
+			return nil, err
+		}
+		for j := 0; j < constants; j++ {
+			var c runtimeConstant
+			if c.name, err = d.text(); err != nil {
+				return nil, err
+			}
+			if _, err = d.typeID(); err != nil {
+				return nil, err
+			}
+			if c.value, err = d.bytes(); err != nil {
+				return nil, err
+			}
+			if _, err = d.texts(); err != nil {
+				return nil, err
+			}
+			p.constants = append(p.constants, c)
+		}
+
+		if _, err = d.optionalTypeID(); err != nil { // errors
+			return nil, err
+		}
+		if p.index, err = d.byte(); err != nil {
+			return nil, err
+		}
+		if version >= 15 {
+			if _, err = d.texts(); err != nil {
+				return nil, err
+			}
+		}
+		pallets = append(pallets, p)
+	}
+	return pallets, nil
+}
+
+// skipStorage reads past the optional storage metadata of a pallet
+func (d *scaleDecoder) skipStorage() error {
+	present, err := d.optionFlag()
+	if err != nil || !present {
+		return err
+	}
+	if _, err := d.text(); err != nil { // prefix
+		return err
+	}
+
+	n, err := d.length()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		if _, err := d.text(); err != nil {
+			return err
+		}
+		if _, err := d.byte(); err != nil { // modifier
+			return err
+		}
+		kind, err := d.byte()
+		if err != nil {
+			return err
+		}
+		switch kind {
+		case 0: // plain
+			_, err = d.typeID()
+		case 1: // map with hashers, key and value
+			if _, err = d.bytes(); err == nil {
+				if _, err = d.typeID(); err == nil {
+					_, err = d.typeID()
+				}
+			}
+		default:
+			err = fmt.Errorf("unknown storage entry type %d", kind)
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := d.bytes(); err != nil { // default
+			return err
+		}
+		if _, err := d.texts(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *scaleDecoder) portableFields() ([]portableField, error) {
+	n, err := d.length()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]portableField, 0, n)
+	for i := 0; i < n; i++ {
+		var f portableField
+		present, err := d.optionFlag()
+		if err != nil {
+			return nil, err
+		}
+		if present {
+			if f.name, err = d.text(); err != nil {
+				return nil, err
+			}
+		}
+		if f.ty, err = d.typeID(); err != nil {
+			return nil, err
+		}
+		if present, err = d.optionFlag(); err != nil { // type name
+			return nil, err
+		}
+		if present {
+			if _, err = d.text(); err != nil {
+				return nil, err
+			}
+		}
+		if _, err = d.texts(); err != nil {
+			return nil, err
+		}
+		fields = append(fields, f)
+	}
+	return fields, nil
+}
+
+func (d *scaleDecoder) bytes() ([]byte, error) {
+	n, err := d.length()
+	if err != nil {
+		return nil, err
+	}
+	return d.read(n)
+}
+
+func (d *scaleDecoder) text() (string, error) {
+	b, err := d.bytes()
+	return string(b), err
+}
+
+func (d *scaleDecoder) texts() ([]string, error) {
+	n, err := d.length()
+	if err != nil {
+		return nil, err
+	}
+	texts := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		s, err := d.text()
+		if err != nil {
+			return nil, err
+		}
+		texts = append(texts, s)
+	}
+	return texts, nil
+}
+
+func (d *scaleDecoder) typeID() (int, error) {
+	n, err := d.compact()
+	if err != nil {
+		return 0, err
+	}
+	if !n.IsInt64() || n.Int64() > 1<<32 {
+		return 0, fmt.Errorf("invalid type id %s", n)
+	}
+	return int(n.Int64()), nil
+}
+
+func (d *scaleDecoder) optionFlag() (bool, error) {
+	flag, err := d.byte()
+	if err != nil {
+		return false, err
+	}
+	if flag > 1 {
+		return false, fmt.Errorf("invalid option flag %d", flag)
+	}
+	return flag == 1, nil
+}
+
+func (d *scaleDecoder) optionalTypeID() (*int, error) {
+	present, err := d.optionFlag()
+	if err != nil || !present {
+		return nil, err
+	}
+	id, err := d.typeID()
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// runtimeTypes names registry types in the Rust-style notation of Metadata
+// and collects the definitions of named types
+type runtimeTypes struct {
+	types map[int]portableType
+	names map[int]string
+	taken map[string]int
+	defs  map[string]TypeDef
+}
+
+func (r *runtimeTypes) fields(fields []portableField) ([]FieldMetadata, error) {
+	defs := make([]FieldMetadata, 0, len(fields))
+	for _, f := range fields {
+		typ, err := r.name(f.ty)
+		if err != nil {
+			return nil, err
+		}
+		defs = append(defs, FieldMetadata{Name: f.name, Type: typ})
+	}
+	return defs, nil
+}
+
+func (r *runtimeTypes) name(id int) (string, error) {
+	if name, ok := r.names[id]; ok {
+		return name, nil
+	}
+	t, ok := r.types[id]
+	if !ok {
+		return "", fmt.Errorf("unknown type id %d", id)
+	}
+
+	var name string
+	var err error
+	switch t.kind {
+	case typeDefPrimitive:
+		if t.primitive >= len(primitiveNames) {
+			return "", fmt.Errorf("unknown primitive %d", t.primitive)
+		}
+		name = primitiveNames[t.primitive]
+		if name == "str" {
+			name = "Bytes"
+		}
+	case typeDefCompact:
+		if name, err = r.name(t.elem); err == nil {
+			name = "Compact<" + name + ">"
+		}
+	case typeDefSequence:
+		name = "Bytes"
+		if !r.isU8(t.elem) {
+			if name, err = r.name(t.elem); err == nil {
+				name = "Vec<" + name + ">"
+			}
+		}
+	case typeDefArray:
+		if r.isU8(t.elem) {
+			name = fmt.Sprintf("[u8; %d]", t.length)
+			break
+		}
+		// Other arrays encode like a tuple of their elements
+		elems := make([]int, t.length)
+		for i := range elems {
+			elems[i] = t.elem
+		}
+		name, err = r.tuple(elems)
+	case typeDefTuple:
+		name, err = r.tuple(t.tuple)
+	case typeDefBitSequence:
+		// Not supported by the encoder and decoder
+		name = "BitVec"
+	default:
+		return r.named(id, t)
+	}
+	if err != nil {
+		return "", err
+	}
+	r.names[id] = name
+	return name, nil
+}
+
+func (r *runtimeTypes) tuple(elems []int) (string, error) {
+	names := make([]string, len(elems))
+	for i, elem := range elems {
+		name, err := r.name(elem)
+		if err != nil {
+			return "", err
+		}
+		names[i] = name
+	}
+	return "(" + strings.Join(names, ", ") + ")", nil
+}
+
+// named names a composite or variant type, defining it in defs unless the
+// encoder and decoder handle it themselves
+func (r *runtimeTypes) named(id int, t portableType) (string, error) {
+	last := ""
+	if len(t.path) > 0 {
+		last = t.path[len(t.path)-1]
+	}
+
+	switch {
+	case last == "AccountId32" || (last == "H256" && t.kind == typeDefComposite):
+		r.names[id] = last
+		return last, nil
+	case len(t.path) == 1 && last == "Option" && len(t.params) == 1 && t.params[0] != nil:
+		inner, err := r.name(*t.params[0])
+		if err != nil {
+			return "", err
+		}
+		r.names[id] = "Option<" + inner + ">"
+		return r.names[id], nil
+	case t.kind == typeDefVariant && (last == "RuntimeCall" ||
+		(last == "Call" && len(t.path) == 2 && strings.HasSuffix(t.path[0], "runtime"))):
+		r.names[id] = "RuntimeCall"
+		return "RuntimeCall", nil
+	}
+
+	name := r.uniqueName(id, t.path)
+	// Named before its definition so recursive types refer to it
+	r.names[id] = name
+
+	var def TypeDef
+	if t.kind == typeDefVariant {
+		def.Variants = []VariantMetadata{}
+		for _, v := range t.variants {
+			fields, err := r.fields(v.fields)
+			if err != nil {
+				return "", fmt.Errorf("%s::%s: %w", name, v.name, err)
+			}
+			def.Variants = append(def.Variants, VariantMetadata{Name: v.name, Index: v.index, Fields: fields})
+		}
+		r.defs[name] = def
+		return name, nil
+	}
+
+	fields, err := r.fields(t.fields)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	switch {
+	case len(fields) == 0 || fields[0].Name != "":
+		def.Fields = fields
+	case len(fields) == 1:
+		// Newtypes such as Perbill or BoundedVec encode as their field
+		def.Alias = fields[0].Type
+	default:
+		types := make([]string, len(fields))
+		for i, f := range fields {
+			types[i] = f.Type
+		}
+		def.Alias = "(" + strings.Join(types, ", ") + ")"
+	}
+	r.defs[name] = def
+	return name, nil
+}
+
+// uniqueName picks the last path segment as the name of a type, falling back
+// to the full path and then the type id when it is taken
+func (r *runtimeTypes) uniqueName(id int, path []string) string {
+	candidates := []string{fmt.Sprintf("Type%d", id)}
+	if len(path) > 0 {
+		candidates = []string{path[len(path)-1], strings.Join(path, "::"), fmt.Sprintf("%s%d", path[len(path)-1], id)}
+	}
+	for _, name := range candidates {
+		if owner, ok := r.taken[name]; (!ok || owner == id) && !reservedTypeNames[name] && !isBuiltinTypeName(name) {
+			r.taken[name] = id
+			return name
+		}
+	}
+	name := fmt.Sprintf("Type%d", id)
+	r.taken[name] = id
+	return name
+}
+
+func (r *runtimeTypes) isU8(id int) bool {
+	t, ok := r.types[id]
+	return ok && t.kind == typeDefPrimitive && t.primitive == 3
+}
+
+func isBuiltinTypeName(name string) bool {
+	for _, p := range primitiveNames {
+		if name == p {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,285 @@
+package scale
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/vedhavyas/go-subkey/v2"
+)
+
+// runtimeMetadataBuilder SCALE-encodes runtime metadata for tests
+type runtimeMetadataBuilder struct {
+	bytes.Buffer
+}
+
+func (b *runtimeMetadataBuilder) compact(n int) {
+	b.Write(CompactLength(n))
+}
+
+func (b *runtimeMetadataBuilder) text(s string) {
+	b.compact(len(s))
+	b.WriteString(s)
+}
+
+func (b *runtimeMetadataBuilder) texts(texts ...string) {
+	b.compact(len(texts))
+	for _, s := range texts {
+		b.text(s)
+	}
+}
+
+// option writes an optional type id; negative ids are None
+func (b *runtimeMetadataBuilder) option(id int) {
+	if id < 0 {
+		b.WriteByte(0)
+		return
+	}
+	b.WriteByte(1)
+	b.compact(id)
+}
+
+// typ writes a registry entry without type parameters
+func (b *runtimeMetadataBuilder) typ(id int, path []string, kind byte, def func()) {
+	b.compact(id)
+	b.texts(path...)
+	b.compact(0)
+	b.WriteByte(kind)
+	def()
+	b.texts()
+}
+
+// fields writes named fields, or unnamed ones when a name is empty
+func (b *runtimeMetadataBuilder) fields(fields ...portableField) {
+	b.compact(len(fields))
+	for _, f := range fields {
+		if f.name == "" {
+			b.WriteByte(0)
+		} else {
+			b.WriteByte(1)
+			b.text(f.name)
+		}
+		b.compact(f.ty)
+		b.WriteByte(0) // type name
+		b.texts("docs are skipped")
+	}
+}
+
+func (b *runtimeMetadataBuilder) variants(variants ...portableVariant) {
+	b.compact(len(variants))
+	for _, v := range variants {
+		b.text(v.name)
+		b.fields(v.fields...)
+		b.WriteByte(v.index)
+		b.texts()
+	}
+}
+
+func buildRuntimeMetadata(version byte) []byte {
+	b := &runtimeMetadataBuilder{}
+	b.Write(runtimeMetadataMagic)
+	b.WriteByte(version)
+
+	b.compact(15)
+	b.typ(0, nil, typeDefPrimitive, func() { b.WriteByte(3) }) // u8
+	b.typ(1, nil, typeDefPrimitive, func() { b.WriteByte(5) }) // u32
+	b.typ(2, nil, typeDefCompact, func() { b.compact(1) })     // Compact<u32>
+	b.typ(3, nil, typeDefArray, func() { b.Write([]byte{32, 0, 0, 0}); b.compact(0) })
+	b.typ(4, []string{"sp_core", "crypto", "AccountId32"}, typeDefComposite, func() {
+		b.fields(portableField{ty: 3})
+	})
+	b.typ(5, []string{"sp_runtime", "multiaddress", "MultiAddress"}, typeDefVariant, func() {
+		b.variants(
+			portableVariant{name: "Id", index: 0, fields: []portableField{{ty: 4}}},
+			portableVariant{name: "Raw", index: 2, fields: []portableField{{ty: 6}}},
+		)
+	})
+	b.typ(6, nil, typeDefSequence, func() { b.compact(0) })    // Vec<u8>
+	b.typ(7, nil, typeDefPrimitive, func() { b.WriteByte(4) }) // u16
+	b.typ(8, []string{"pallet_child_bounties", "pallet", "Call"}, typeDefVariant, func() {
+		b.variants(
+			portableVariant{name: "award_child_bounty", index: 4, fields: []portableField{
+				{name: "parent_bounty_id", ty: 2}, {name: "child_bounty_id", ty: 2}, {name: "beneficiary", ty: 5},
+			}},
+			portableVariant{name: "claim_child_bounty", index: 5, fields: []portableField{
+				{name: "parent_bounty_id", ty: 2}, {name: "child_bounty_id", ty: 2},
+			}},
+		)
+	})
+	b.typ(9, []string{"polkadot_runtime", "RuntimeCall"}, typeDefVariant, func() {
+		b.variants(
+			portableVariant{name: "Utility", index: 26, fields: []portableField{{ty: 10}}},
+			portableVariant{name: "ChildBounties", index: 38, fields: []portableField{{ty: 8}}},
+		)
+	})
+	b.typ(10, []string{"pallet_utility", "pallet", "Call"}, typeDefVariant, func() {
+		b.variants(
+			portableVariant{name: "batch", index: 0, fields: []portableField{{name: "calls", ty: 11}}},
+			portableVariant{name: "set_ratio", index: 1, fields: []portableField{{name: "ratio", ty: 13}, {name: "limit", ty: 14}}},
+		)
+	})
+	b.typ(11, nil, typeDefSequence, func() { b.compact(9) }) // Vec<RuntimeCall>
+	b.typ(12, []string{"sp_arithmetic", "per_things", "Perbill"}, typeDefComposite, func() {
+		b.fields(portableField{ty: 1})
+	})
+	b.typ(13, nil, typeDefCompact, func() { b.compact(12) })
+	// Option<u16> carries its type parameter
+	b.compact(14)
+	b.texts("Option")
+	b.compact(1)
+	b.text("T")
+	b.option(7)
+	b.WriteByte(typeDefVariant)
+	b.variants(
+		portableVariant{name: "None", index: 0},
+		portableVariant{name: "Some", index: 1, fields: []portableField{{ty: 7}}},
+	)
+	b.texts()
+
+	spec := &runtimeMetadataBuilder{}
+	spec.text("polkadot")
+	spec.text("parity-polkadot")
+	spec.Write([]byte{0, 0, 0, 0})
+	spec.Write(binary.LittleEndian.AppendUint32(nil, 1002000))
+
+	pallet := func(name string, index byte, calls int, storage, constants func()) {
+		b.text(name)
+		if storage == nil {
+			b.WriteByte(0)
+		} else {
+			b.WriteByte(1)
+			storage()
+		}
+		b.option(calls)
+		b.option(-1) // events
+		if constants == nil {
+			b.compact(0)
+		} else {
+			constants()
+		}
+		b.option(-1) // errors
+		b.WriteByte(index)
+		if version >= 15 {
+			b.texts("pallet docs")
+		}
+	}
+
+	b.compact(3)
+	pallet("System", 0, -1, nil, func() {
+		b.compact(2)
+		b.text("SS58Prefix")
+		b.compact(7)
+		b.compact(2)
+		b.Write([]byte{2, 0})
+		b.texts()
+		b.text("Version")
+		b.compact(1)
+		b.compact(spec.Len())
+		b.Write(spec.Bytes())
+		b.texts()
+	})
+	pallet("Utility", 26, 10, func() {
+		b.text("Utility")
+		b.compact(2)
+		b.text("Plain")
+		b.WriteByte(0)
+		b.WriteByte(0)
+		b.compact(1)
+		b.compact(4)
+		b.Write([]byte{0, 0, 0, 0})
+		b.texts()
+		b.text("Map")
+		b.WriteByte(1)
+		b.WriteByte(1)
+		b.compact(2)
+		b.Write([]byte{0, 6})
+		b.compact(1)
+		b.compact(4)
+		b.compact(0)
+		b.texts("a map")
+	}, nil)
+	pallet("ChildBounties", 38, 8, nil, nil)
+
+	// The extrinsic metadata and anything after it are not read
+	b.Write([]byte{0xff, 0xff})
+	return b.Bytes()
+}
+
+func TestParseRuntimeMetadata(t *testing.T) {
+	m, err := ParseRuntimeMetadata(buildRuntimeMetadata(14))
+	if err != nil {
+		t.Fatalf("ParseRuntimeMetadata failed: %v", err)
+	}
+
+	if m.SS58Prefix != 2 || m.SpecVersion != 1002000 {
+		t.Errorf("unexpected system constants: prefix %d, spec %d", m.SS58Prefix, m.SpecVersion)
+	}
+	if len(m.Pallets) != 2 || m.Pallets[0].Name != "Utility" || m.Pallets[1].Index != 38 {
+		t.Fatalf("expected the pallets with calls, got %+v", m.Pallets)
+	}
+	if def := m.Types["Perbill"]; def.Alias != "u32" {
+		t.Errorf("expected Perbill as an alias of u32, got %+v", def)
+	}
+	if def := m.Types["MultiAddress"]; len(def.Variants) != 2 || def.Variants[0].Fields[0].Type != "AccountId32" {
+		t.Errorf("unexpected MultiAddress: %+v", def)
+	}
+	_, setRatio, err := m.Call("utility", "setRatio")
+	if err != nil || setRatio.Args[0].Type != "Compact<Perbill>" || setRatio.Args[1].Type != "Option<u16>" {
+		t.Errorf("unexpected setRatio call: %+v, %v", setRatio, err)
+	}
+
+	account := bytes.Repeat([]byte{0xd4}, 32)
+	award, err := DecodeCallHex(m, "0x2604140800"+hex.EncodeToString(account))
+	if err != nil {
+		t.Fatalf("decode award: %v", err)
+	}
+	want := EnumValue{Variant: "Id", Value: subkey.SS58Encode(account, m.SS58Prefix)}
+	if beneficiary, _ := award.Arg("beneficiary"); award.String() != "childBounties.awardChildBounty" || beneficiary != want {
+		t.Errorf("unexpected award call: %s with %v", award, beneficiary)
+	}
+
+	batch, err := DecodeCallHex(m, "0x1a000426051408")
+	if err != nil {
+		t.Fatalf("decode batch: %v", err)
+	}
+	if calls := batch.Calls(); len(calls) != 1 || calls[0].String() != "childBounties.claimChildBounty" {
+		t.Errorf("unexpected batch: %s", batch.Pretty())
+	}
+
+	// The converted metadata can be stored and loaded as JSON
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := ParseMetadata(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := EncodeCall(loaded, Call{Pallet: "utility", Method: "setRatio", Args: map[string]interface{}{"ratio": 5, "limit": nil}})
+	if err != nil || hex.EncodeToString(encoded) != "1a011400" {
+		t.Errorf("unexpected setRatio encoding %x, %v", encoded, err)
+	}
+}
+
+func TestParseRuntimeMetadataVersions(t *testing.T) {
+	v15 := buildRuntimeMetadata(15)
+	if m, err := ParseRuntimeMetadata(v15); err != nil || len(m.Pallets) != 2 {
+		t.Errorf("expected V15 metadata to parse, got %v", err)
+	}
+
+	// The runtime API returns the metadata with a length prefix
+	if _, err := ParseRuntimeMetadataHex("0x" + hex.EncodeToString(append(CompactLength(len(v15)), v15...))); err != nil {
+		t.Errorf("expected length-prefixed metadata to parse, got %v", err)
+	}
+
+	v13 := buildRuntimeMetadata(14)
+	v13[len(runtimeMetadataMagic)] = 13
+	if _, err := ParseRuntimeMetadata(v13); err == nil {
+		t.Error("expected V13 metadata to be rejected")
+	}
+	if _, err := ParseRuntimeMetadata([]byte("not metadata")); err == nil {
+		t.Error("expected an error without the magic number")
+	}
+}
@@ -0,0 +1,125 @@
+package polkassembly
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEncodeGovernanceCalls(t *testing.T) {
+	m, err := DefaultMetadata("polkadot")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	aliceID, err := AccountID(aliceAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vote, err := CreateVoteRequest{PostID: 100, Vote: DecisionAye, Balance: "10000000000", LockPeriod: 1}.VoteCall()
+	if err != nil {
+		t.Fatal(err)
+	}
+	split, err := VoteCall(5, DecisionSplit, ConvictionNone, CartAmount{Aye: "1", Nay: "2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	delegate, err := Delegate{Address: aliceAddress}.DelegateCall(0, ConvictionLocked1x, "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u128 := func(n string) string {
+		v, _ := new(big.Int).SetString(n, 10)
+		b := v.FillBytes(make([]byte, 16))
+		for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+			b[i], b[j] = b[j], b[i]
+		}
+		return hex.EncodeToString(b)
+	}
+
+	track := 1
+	tests := []struct {
+		name string
+		call Call
+		want string
+	}{
+		{"vote", vote, "1400" + "9101" + "00" + "81" + "00e40b54020000000000000000000000"},
+		{"split", split, "1400" + "14" + "01" + u128("1") + u128("2")},
+		{"delegate", delegate, "1401" + "0000" + "00" + hex.EncodeToString(aliceID) + "01" + u128("1")},
+		{"undelegate", UndelegateCall(1), "1402" + "0100"},
+		{"removeVote ongoing", RemoveVoteCall(nil, 5), "1404" + "00" + "05000000"},
+		{"removeVote", RemoveVoteCall(&track, 5), "1404" + "010100" + "05000000"},
+		{"placeDecisionDeposit", PlaceDecisionDepositCall(7), "1501" + "07000000"},
+		{"batchAll", BatchAll(UndelegateCall(1), PlaceDecisionDepositCall(7)), "1a02" + "08" + "14020100" + "150107000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.call.Hex(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != "0x"+tt.want {
+				t.Errorf("got %s, want 0x%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateVoteRequestAbstain(t *testing.T) {
+	var req CreateVoteRequest
+	if err := json.Unmarshal([]byte(`{"postId":7,"vote":"abstain","balance":"500"}`), &req); err != nil {
+		t.Fatal(err)
+	}
+	call, err := req.VoteCall()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vote := call.Args.(VoteCallArgs).Vote.SplitAbstain
+	if vote == nil {
+		t.Fatalf("expected a split-abstain vote, got %+v", call.Args)
+	}
+	if vote.Abstain != "500" || vote.Aye != "0" || vote.Nay != "0" {
+		t.Errorf("unexpected split-abstain amounts %+v", vote)
+	}
+}
+
+func TestLoadMetadata(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metadata.json")
+	data := `{
+		"pallets": [{"name": "Referenda", "index": 42, "calls": [
+			{"name": "place_decision_deposit", "index": 1, "args": [{"name": "index", "type": "PollIndex"}]}
+		]}],
+		"types": {"PollIndex": "u32"}
+	}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := LoadMetadata(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := PlaceDecisionDepositCall(7).Hex(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "0x2a0107000000" {
+		t.Errorf("got %s", got)
+	}
+
+	if _, err := UndelegateCall(1).Hex(m); err == nil || !strings.Contains(err.Error(), "convictionVoting") {
+		t.Errorf("expected unknown pallet error, got %v", err)
+	}
+
+	if ext := EncodeUnsignedExtrinsic([]byte{0x2a, 0x01}); hex.EncodeToString(ext) != "0c042a01" {
+		t.Errorf("unexpected unsigned extrinsic %x", ext)
+	}
+}
@@ -0,0 +1,368 @@
+package polkassembly
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Search result types
+const (
+	SearchTypeAll      = "all"
+	SearchTypePosts    = "posts"
+	SearchTypeComments = "comments"
+	SearchTypeUsers    = "users"
+)
+
+const (
+	searchPageSize = 100
+	// maxSearchPages bounds how many listing pages the client-side search scans
+	maxSearchPages = 10
+	// maxCommentSearchPosts bounds the posts whose comments the client-side
+	// search fetches, one request each
+	maxCommentSearchPosts = 25
+	defaultSearchLimit    = 20
+)
+
+// Search searches posts, comments and users. Results outside the author,
+// tag, date, status or track filters are dropped from the server response;
+// comments there carry no parent post, so only the author and date filters
+// apply to them. When the search endpoint is unavailable it falls back to
+// scanning paged listings client-side, where comments also take the tag,
+// status and track filters from their post.
+func (c *Client) Search(params SearchParams) (*SearchResponse, error) {
+	if err := validateSearch(params); err != nil {
+		return nil, err
+	}
+
+	req := c.client.R().SetQueryParams(searchQueryParams(params))
+	if params.Network != "" {
+		req.SetHeader("x-network", params.Network)
+	}
+	r, err := req.Get("/search")
+	if err != nil {
+		return nil, err
+	}
+	if endpointUnavailable(r) {
+		c.logDebug("Search endpoint unavailable (HTTP %d), searching listings", r.StatusCode())
+		return c.searchListings(params)
+	}
+
+	var resp SearchResponse
+	if err := c.parseResponse(r, &resp); err != nil {
+		return nil, err
+	}
+	if filtered := FilterSearchResponse(resp, params); searchResultCount(filtered) < searchResultCount(resp) {
+		c.logDebug("Search endpoint ignored some filters, dropped %d results", searchResultCount(resp)-searchResultCount(filtered))
+		resp = filtered
+	}
+
+	if resp.Page == 0 {
+		resp.Page = params.Page
+	}
+	if resp.Limit == 0 {
+		resp.Limit = params.Limit
+	}
+	return &resp, nil
+}
+
+// FilterSearchResponse applies the author, tag, date range, status and track
+// filters of params to search results, and the author and date range filters
+// to comments. The query itself is left to the server, which may match more
+// loosely than a substring search. TotalCount is lowered by the results
+// dropped, so it stays an upper bound when other pages hold more of them.
+func FilterSearchResponse(resp SearchResponse, params SearchParams) SearchResponse {
+	filtered := resp
+	filtered.Posts = nil
+	for _, p := range resp.Posts {
+		if postMatchesSearch(p, params, true) {
+			filtered.Posts = append(filtered.Posts, p)
+		}
+	}
+	filtered.Comments = nil
+	for _, comment := range resp.Comments {
+		if commentMatchesSearch(comment, params) {
+			filtered.Comments = append(filtered.Comments, comment)
+		}
+	}
+
+	if dropped := searchResultCount(resp) - searchResultCount(filtered); dropped > 0 {
+		filtered.TotalCount -= dropped
+		if filtered.TotalCount < searchResultCount(filtered) {
+			filtered.TotalCount = searchResultCount(filtered)
+		}
+	}
+	return filtered
+}
+
+// searchListings searches posts, comments and users client-side by paging
+// through listings. Only the network of the client can be searched.
+func (c *Client) searchListings(params SearchParams) (*SearchResponse, error) {
+	if params.Network != "" && params.Network != c.network {
+		return nil, fmt.Errorf("client-side search only covers network %s, not %s", c.network, params.Network)
+	}
+
+	var posts []Post
+	var comments []Comment
+	var users []User
+
+	searchType := strings.ToLower(params.Type)
+	if searchType == "" {
+		searchType = SearchTypeAll
+	}
+
+	if searchType == SearchTypeAll || searchType == SearchTypePosts || searchType == SearchTypeComments {
+		scanned, err := c.searchPosts(params)
+		if err != nil {
+			return nil, err
+		}
+
+		commentPosts := 0
+		for _, p := range scanned {
+			if searchType != SearchTypeComments && postMatchesSearch(p, params, true) && textMatches(params.Query, p.Title, p.Content) {
+				posts = append(posts, p)
+			}
+			if searchType == SearchTypePosts || commentPosts >= maxCommentSearchPosts || !postMatchesSearch(p, params, false) {
+				continue
+			}
+
+			commentPosts++
+			postComments, err := c.GetPostCommentsByType(p.PostID, p.ProposalType)
+			if err != nil {
+				c.logDebug("Could not load comments of %s %d: %v", p.ProposalType, p.PostID, err)
+				continue
+			}
+			for _, comment := range flattenComments(postComments) {
+				if !comment.IsDeleted && commentMatchesSearch(comment, params) && textMatches(params.Query, commentText(comment.Content)) {
+					comments = append(comments, comment)
+				}
+			}
+		}
+	}
+
+	// Users only have text to match, so filter-only searches skip them
+	if (searchType == SearchTypeAll || searchType == SearchTypeUsers) && strings.TrimSpace(params.Query) != "" {
+		for page := 1; page <= maxSearchPages; page++ {
+			resp, err := c.GetUsers(UserListingParams{Page: page, Limit: searchPageSize})
+			if err != nil {
+				return nil, err
+			}
+			for _, u := range resp.Users {
+				if textMatches(params.Query, u.Username, u.Title, u.Bio) {
+					users = append(users, u)
+				}
+			}
+			if len(resp.Users) < searchPageSize {
+				break
+			}
+		}
+	}
+
+	page, limit := params.Page, params.Limit
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	resp := &SearchResponse{
+		TotalCount: len(posts) + len(comments) + len(users),
+		Page:       page,
+		Limit:      limit,
+	}
+	start, end := pageBounds(len(posts), page, limit)
+	resp.Posts = posts[start:end]
+	start, end = pageBounds(len(comments), page, limit)
+	resp.Comments = comments[start:end]
+	start, end = pageBounds(len(users), page, limit)
+	resp.Users = users[start:end]
+	return resp, nil
+}
+
+// searchPosts pages through the posts the search can match. A track filter
+// limits the scan to referenda.
+func (c *Client) searchPosts(params SearchParams) ([]Post, error) {
+	types := []ProposalType{ProposalTypeReferendumV2, ProposalTypeDiscussion}
+	if params.TrackNo > 0 {
+		types = types[:1]
+	}
+
+	var posts []Post
+	for _, t := range types {
+		for page := 1; page <= maxSearchPages; page++ {
+			resp, err := c.GetPosts(PostListingParams{
+				ProposalType: t,
+				Page:         page,
+				ListingLimit: searchPageSize,
+				TrackNo:      params.TrackNo,
+				TrackStatus:  params.Status,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("list %s posts: %w", t, err)
+			}
+			for _, p := range resp.Posts {
+				if p.ProposalType == "" {
+					p.ProposalType = t
+				}
+				posts = append(posts, p)
+			}
+			if len(resp.Posts) < searchPageSize {
+				break
+			}
+		}
+	}
+	return posts, nil
+}
+
+// postMatchesSearch checks the post filters of params. withAuthor also
+// checks the author and date range, which for comment searches apply to the
+// comment instead. Posts that do not report a track are kept.
+func postMatchesSearch(p Post, params SearchParams, withAuthor bool) bool {
+	if params.Status != "" {
+		status := p.Status
+		if status == "" && p.OnChainInfo != nil {
+			status = p.OnChainInfo.Status
+		}
+		if !strings.EqualFold(status, params.Status) {
+			return false
+		}
+	}
+	if params.TrackNo > 0 && p.TrackNumber != 0 && p.TrackNumber != params.TrackNo {
+		return false
+	}
+	if !hasAllTags(p.Tags, params.Tags) {
+		return false
+	}
+	if !withAuthor {
+		return true
+	}
+
+	if params.Author != "" {
+		username := p.Username
+		if username == "" && p.PublicUser != nil {
+			username = p.PublicUser.Username
+		}
+		if !strings.EqualFold(username, params.Author) && !SameAccount(p.ProposerAddress, params.Author) {
+			return false
+		}
+	}
+	return inDateRange(p.CreatedAt, params.DateFrom, params.DateTo)
+}
+
+func commentMatchesSearch(comment Comment, params SearchParams) bool {
+	if params.Author != "" && !strings.EqualFold(comment.Username, params.Author) {
+		return false
+	}
+	return inDateRange(comment.CreatedAt, params.DateFrom, params.DateTo)
+}
+
+// inDateRange reports whether t is within [from, to]. Zero bounds are open.
+func inDateRange(t, from, to time.Time) bool {
+	if !from.IsZero() && t.Before(from) {
+		return false
+	}
+	if !to.IsZero() && t.After(to) {
+		return false
+	}
+	return true
+}
+
+// textMatches reports whether any of fields contains query, ignoring case. An
+// empty query matches everything.
+func textMatches(query string, fields ...string) bool {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return true
+	}
+	for _, f := range fields {
+		if strings.Contains(strings.ToLower(f), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// commentText flattens comment content, which is plain text or a rich text
+// document, into a searchable string
+func commentText(content interface{}) string {
+	switch v := content.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	b, err := json.Marshal(content)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+func searchResultCount(resp SearchResponse) int {
+	return len(resp.Posts) + len(resp.Comments) + len(resp.Users)
+}
+
+// pageBounds returns the slice bounds of a 1-based page of n items
+func pageBounds(n, page, limit int) (int, int) {
+	start := (page - 1) * limit
+	if start > n {
+		start = n
+	}
+	end := start + limit
+	if end > n {
+		end = n
+	}
+	return start, end
+}
+
+func searchQueryParams(params SearchParams) map[string]string {
+	queryParams := make(map[string]string)
+	if params.Query != "" {
+		queryParams["query"] = params.Query
+	}
+	if params.Type != "" {
+		queryParams["type"] = params.Type
+	}
+	if params.Author != "" {
+		queryParams["author"] = params.Author
+	}
+	if len(params.Tags) > 0 {
+		queryParams["tags"] = strings.Join(params.Tags, ",")
+	}
+	if !params.DateFrom.IsZero() {
+		queryParams["dateFrom"] = params.DateFrom.UTC().Format(time.RFC3339)
+	}
+	if !params.DateTo.IsZero() {
+		queryParams["dateTo"] = params.DateTo.UTC().Format(time.RFC3339)
+	}
+	if params.Status != "" {
+		queryParams["status"] = params.Status
+	}
+	if params.TrackNo > 0 {
+		queryParams["trackNo"] = fmt.Sprintf("%d", params.TrackNo)
+	}
+	if params.Page > 0 {
+		queryParams["page"] = fmt.Sprintf("%d", params.Page)
+	}
+	if params.Limit > 0 {
+		queryParams["limit"] = fmt.Sprintf("%d", params.Limit)
+	}
+	return queryParams
+}
+
+func validateSearch(params SearchParams) error {
+	switch strings.ToLower(params.Type) {
+	case "", SearchTypeAll, SearchTypePosts, SearchTypeComments, SearchTypeUsers:
+	default:
+		return fmt.Errorf("invalid search type: %q", params.Type)
+	}
+	if !params.DateFrom.IsZero() && !params.DateTo.IsZero() && params.DateTo.Before(params.DateFrom) {
+		return fmt.Errorf("search date range ends before it starts")
+	}
+	if strings.TrimSpace(params.Query) == "" && params.Author == "" && len(params.Tags) == 0 &&
+		params.DateFrom.IsZero() && params.DateTo.IsZero() && params.Status == "" && params.TrackNo == 0 {
+		return fmt.Errorf("search needs a query or a filter")
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package polkassembly
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFilterSearchResponse(t *testing.T) {
+	day := time.Date(2024, 4, 10, 0, 0, 0, 0, time.UTC)
+	resp := SearchResponse{
+		Posts: []Post{
+			{Index: 1, Username: "alice", Status: "Deciding", TrackNumber: 33, Tags: []string{"Treasury"}, CreatedAt: day},
+			{Index: 2, Username: "bob", Status: "Deciding", TrackNumber: 33, CreatedAt: day},
+			{Index: 3, ProposerAddress: aliceAddress, OnChainInfo: &OnChainInfo{Status: "deciding"}, Tags: []string{"treasury"}, CreatedAt: day.Add(48 * time.Hour)},
+		},
+		Comments: []Comment{
+			{Username: "alice", CreatedAt: day},
+			{Username: "Alice", CreatedAt: day.Add(-48 * time.Hour)},
+		},
+		TotalCount: 40,
+	}
+
+	filtered := FilterSearchResponse(resp, SearchParams{Author: "alice", Status: "Deciding", TrackNo: 33, Tags: []string{"treasury"}})
+	if len(filtered.Posts) != 1 || filtered.Posts[0].Index != 1 || len(filtered.Comments) != 2 {
+		t.Errorf("unexpected author filter result: %+v", filtered)
+	}
+
+	if filtered.TotalCount != 38 {
+		t.Errorf("expected the total lowered by the 2 dropped posts, got %d", filtered.TotalCount)
+	}
+
+	byAddress := FilterSearchResponse(resp, SearchParams{Author: aliceAddress})
+	if len(byAddress.Posts) != 1 || byAddress.Posts[0].Index != 3 {
+		t.Errorf("expected post 3 by proposer address, got %+v", byAddress.Posts)
+	}
+
+	inRange := FilterSearchResponse(resp, SearchParams{DateFrom: day.Add(-time.Hour), DateTo: day.Add(time.Hour)})
+	if len(inRange.Posts) != 2 || len(inRange.Comments) != 1 {
+		t.Errorf("unexpected date filter result: %d posts, %d comments", len(inRange.Posts), len(inRange.Comments))
+	}
+
+	// The total never falls below the results kept
+	if none := FilterSearchResponse(SearchResponse{Posts: resp.Posts}, SearchParams{Author: "alice"}); none.TotalCount != 1 {
+		t.Errorf("expected a total of 1, got %d", none.TotalCount)
+	}
+}
+
+func TestSearchHelpers(t *testing.T) {
+	if !textMatches("", "anything") || !textMatches("FUND", "Treasury funding") || textMatches("x", "abc") {
+		t.Error("unexpected text match")
+	}
+	if text := commentText(map[string]interface{}{"text": "Rich text"}); !textMatches("rich", text) {
+		t.Errorf("rich text comment not searchable: %s", text)
+	}
+
+	if start, end := pageBounds(45, 3, 20); start != 40 || end != 45 {
+		t.Errorf("unexpected bounds for last page: %d-%d", start, end)
+	}
+	if start, end := pageBounds(5, 2, 20); start != 5 || end != 5 {
+		t.Errorf("unexpected bounds past the end: %d-%d", start, end)
+	}
+
+	if err := validateSearch(SearchParams{}); err == nil {
+		t.Error("expected an error for an empty search")
+	}
+	if err := validateSearch(SearchParams{Query: "x", Type: "proposals"}); err == nil {
+		t.Error("expected an error for an invalid type")
+	}
+	if err := validateSearch(SearchParams{Tags: []string{"treasury"}}); err != nil {
+		t.Errorf("unexpected error for a filter-only search: %v", err)
+	}
+}
@@ -0,0 +1,275 @@
+package polkassembly
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Session is an authenticated identity held by a SessionManager
+type Session struct {
+	Key      string `json:"key"`
+	Address  string `json:"address,omitempty"`
+	Username string `json:"username,omitempty"`
+	UserID   int    `json:"userId,omitempty"`
+	Token    string `json:"token"`
+}
+
+// SessionManager holds several authenticated identities and hands out a
+// dedicated Client per identity, so switching accounts never touches the
+// token of another client.
+//
+// Sessions are persisted as a single JSON document through the configured
+// TokenStorage, keyed by address or username.
+type SessionManager struct {
+	mu       sync.RWMutex
+	cfg      Config
+	storage  TokenStorage
+	sessions map[string]*Session
+	clients  map[string]*Client
+}
+
+// NewSessionManager creates a session manager and restores any sessions
+// previously saved to cfg.TokenStorage
+func NewSessionManager(cfg Config) (*SessionManager, error) {
+	m := &SessionManager{
+		cfg:      cfg,
+		storage:  cfg.TokenStorage,
+		sessions: make(map[string]*Session),
+		clients:  make(map[string]*Client),
+	}
+
+	// Per-session clients must not share the single-token storage
+	m.cfg.TokenStorage = nil
+	m.cfg.Token = ""
+
+	if err := m.load(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (m *SessionManager) load() error {
+	if m.storage == nil {
+		return nil
+	}
+
+	data, err := m.storage.GetToken()
+	if err != nil || data == "" {
+		return nil
+	}
+
+	var sessions []*Session
+	if err := json.Unmarshal([]byte(data), &sessions); err != nil {
+		return fmt.Errorf("decode sessions: %w", err)
+	}
+
+	for _, s := range sessions {
+		if s.Key != "" && s.Token != "" {
+			m.sessions[s.Key] = s
+		}
+	}
+
+	return nil
+}
+
+// persist must be called with m.mu held
+func (m *SessionManager) persist() error {
+	if m.storage == nil {
+		return nil
+	}
+
+	if len(m.sessions) == 0 {
+		return m.storage.DeleteToken()
+	}
+
+	sessions := make([]*Session, 0, len(m.sessions))
+	for _, key := range m.keys() {
+		sessions = append(sessions, m.sessions[key])
+	}
+
+	data, err := json.Marshal(sessions)
+	if err != nil {
+		return fmt.Errorf("encode sessions: %w", err)
+	}
+
+	return m.storage.SaveToken(string(data))
+}
+
+func (m *SessionManager) keys() []string {
+	keys := make([]string, 0, len(m.sessions))
+	for key := range m.sessions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (m *SessionManager) newClient() *Client {
+	return NewClient(m.cfg)
+}
+
+// store registers an authenticated client under key and persists it
+func (m *SessionManager) store(key string, client *Client, session *Session) (*Session, error) {
+	if client.token == "" {
+		return nil, fmt.Errorf("no token received for %s", key)
+	}
+
+	session.Key = key
+	session.Token = client.token
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sessions[key] = session
+	m.clients[key] = client
+
+	if err := m.persist(); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// AddToken registers an existing token under key
+func (m *SessionManager) AddToken(key, token string) (*Session, error) {
+	if key == "" {
+		return nil, fmt.Errorf("session key is required")
+	}
+
+	client := m.newClient()
+	client.SetAuthToken(token)
+
+	return m.store(key, client, &Session{})
+}
+
+// AuthenticateWithSigner logs in with signer on a fresh client and stores the
+// session under the signer's address. An *OnBehalfSigner is stored under the
+// proxied or multisig account it acts for, not its delegate key.
+func (m *SessionManager) AuthenticateWithSigner(network string, signer Signer) (*Session, error) {
+	client := m.newClient()
+	if err := client.AuthenticateWithSigner(network, signer); err != nil {
+		return nil, err
+	}
+
+	address := signer.Address()
+	if onBehalf, ok := signer.(*OnBehalfSigner); ok {
+		address = onBehalf.Account
+	}
+
+	return m.store(address, client, &Session{
+		Address: address,
+	})
+}
+
+// AuthenticateWithSeed logs in with a seed phrase and stores the session
+// under the derived address
+func (m *SessionManager) AuthenticateWithSeed(network string, seedPhrase string) (*Session, error) {
+	signer, err := NewPolkadotSignerFromSeed(seedPhrase, ss58Prefix(network))
+	if err != nil {
+		return nil, fmt.Errorf("create signer: %w", err)
+	}
+
+	return m.AuthenticateWithSigner(network, signer)
+}
+
+// Web2Login logs in with username/email and password and stores the session
+// under the returned username
+func (m *SessionManager) Web2Login(req Web2LoginRequest) (*Session, error) {
+	client := m.newClient()
+	resp, err := client.Web2Login(req)
+	if err != nil {
+		return nil, err
+	}
+
+	key := resp.User.Username
+	if key == "" {
+		key = req.EmailOrUsername
+	}
+
+	return m.store(key, client, &Session{
+		Address:  resp.User.Web3Address,
+		Username: resp.User.Username,
+		UserID:   resp.User.ID,
+	})
+}
+
+// Session returns the stored session for key
+func (m *SessionManager) Session(key string) (*Session, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	s, ok := m.sessions[key]
+	if !ok {
+		return nil, false
+	}
+	copied := *s
+	return &copied, true
+}
+
+// Sessions returns all stored sessions ordered by key
+func (m *SessionManager) Sessions() []Session {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	sessions := make([]Session, 0, len(m.sessions))
+	for _, key := range m.keys() {
+		sessions = append(sessions, *m.sessions[key])
+	}
+	return sessions
+}
+
+// Client returns the dedicated client for key, creating it from the stored
+// token if needed
+func (m *SessionManager) Client(key string) (*Client, error) {
+	m.mu.RLock()
+	client, ok := m.clients[key]
+	m.mu.RUnlock()
+	if ok {
+		return client, nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if client, ok := m.clients[key]; ok {
+		return client, nil
+	}
+
+	session, ok := m.sessions[key]
+	if !ok {
+		return nil, fmt.Errorf("no session for %s", key)
+	}
+
+	client = m.newClient()
+	client.SetAuthToken(session.Token)
+	m.clients[key] = client
+
+	return client, nil
+}
+
+// As runs fn with the client authenticated as key
+func (m *SessionManager) As(key string, fn func(c *Client) error) error {
+	client, err := m.Client(key)
+	if err != nil {
+		return err
+	}
+	return fn(client)
+}
+
+// Remove drops the session for key and updates storage
+func (m *SessionManager) Remove(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.sessions[key]; !ok {
+		return fmt.Errorf("no session for %s", key)
+	}
+
+	delete(m.sessions, key)
+	delete(m.clients, key)
+
+	return m.persist()
+}
@@ -0,0 +1,102 @@
+package polkassembly
+
+import (
+	"net/http"
+	"testing"
+)
+
+type memoryTokenStorage struct {
+	token string
+}
+
+func (s *memoryTokenStorage) SaveToken(token string) error {
+	s.token = token
+	return nil
+}
+
+func (s *memoryTokenStorage) GetToken() (string, error) {
+	return s.token, nil
+}
+
+func (s *memoryTokenStorage) DeleteToken() error {
+	s.token = ""
+	return nil
+}
+
+func TestSessionManager(t *testing.T) {
+	storage := &memoryTokenStorage{}
+	cfg := Config{Network: "polkadot", TokenStorage: storage}
+
+	m, err := NewSessionManager(cfg)
+	if err != nil {
+		t.Fatalf("NewSessionManager failed: %v", err)
+	}
+
+	if _, err := m.AddToken("treasury", "token-a"); err != nil {
+		t.Fatalf("AddToken failed: %v", err)
+	}
+	if _, err := m.AddToken("delegate", "token-b"); err != nil {
+		t.Fatalf("AddToken failed: %v", err)
+	}
+
+	err = m.As("treasury", func(c *Client) error {
+		if c.token != "token-a" {
+			t.Errorf("expected token-a, got %s", c.token)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("As failed: %v", err)
+	}
+
+	restored, err := NewSessionManager(cfg)
+	if err != nil {
+		t.Fatalf("restore failed: %v", err)
+	}
+
+	c, err := restored.Client("delegate")
+	if err != nil {
+		t.Fatalf("Client failed: %v", err)
+	}
+	if c.token != "token-b" {
+		t.Errorf("expected token-b, got %s", c.token)
+	}
+
+	if err := restored.Remove("delegate"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if _, err := restored.Client("delegate"); err == nil {
+		t.Error("expected error for removed session")
+	}
+	if len(restored.Sessions()) != 1 {
+		t.Errorf("expected 1 session, got %d", len(restored.Sessions()))
+	}
+}
+
+func TestSessionManagerOnBehalfSigner(t *testing.T) {
+	url, _ := newStubServer(t, map[string]stubResponse{
+		"POST /auth/web3-auth": {Status: http.StatusOK, Body: Web3AuthResponse{Token: "token"}},
+	})
+	m, err := NewSessionManager(Config{BaseURL: url, Network: "polkadot"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// One proxy key acting for two accounts keeps a session for each
+	delegate := fixedSigner{address: aliceAddress}
+	for _, proxied := range []string{bobAddress, charlieAddress} {
+		session, err := m.AuthenticateWithSigner("polkadot", NewProxySigner(delegate, proxied, "Governance"))
+		if err != nil {
+			t.Fatalf("AuthenticateWithSigner failed: %v", err)
+		}
+		if session.Key != proxied || session.Address != proxied {
+			t.Errorf("expected the session keyed by %s, got %+v", proxied, session)
+		}
+	}
+	if len(m.Sessions()) != 2 {
+		t.Errorf("expected 2 sessions, got %d", len(m.Sessions()))
+	}
+	if _, ok := m.Session(aliceAddress); ok {
+		t.Error("expected no session under the delegate key")
+	}
+}
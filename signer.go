@@ -87,3 +87,59 @@ func (s *PolkadotSigner) Sign(message []byte) ([]byte, error) {
 func (s *PolkadotSigner) Address() string {
 	return s.address
 }
+
+// Account kinds that cannot sign for themselves
+const (
+	AccountKindProxy    = "proxy"
+	AccountKindMultisig = "multisig"
+)
+
+// OnBehalfSigner signs with a delegate key for an account that cannot sign
+// messages itself, such as a pure proxy or a multisig
+type OnBehalfSigner struct {
+	Signer // delegate key producing the signatures
+
+	Account     string   // proxied or multisig address
+	Kind        string   // AccountKindProxy or AccountKindMultisig
+	ProxyType   string   // proxy only, e.g. "Governance"
+	Signatories []string // multisig only
+	Threshold   int      // multisig only
+}
+
+// NewProxySigner creates a signer acting for a proxied account via one of its proxies
+func NewProxySigner(delegate Signer, proxied string, proxyType string) *OnBehalfSigner {
+	return &OnBehalfSigner{
+		Signer:    delegate,
+		Account:   proxied,
+		Kind:      AccountKindProxy,
+		ProxyType: proxyType,
+	}
+}
+
+// NewMultisigSigner creates a signer acting for a multisig via one of its
+// signatories. The multisig address is derived from signatories and threshold.
+func NewMultisigSigner(delegate Signer, signatories []string, threshold int, ss58Format uint16) (*OnBehalfSigner, error) {
+	found := false
+	for _, s := range signatories {
+		if SameAccount(s, delegate.Address()) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("signer %s is not a signatory of the multisig", delegate.Address())
+	}
+
+	address, err := MultisigAddress(signatories, threshold, ss58Format)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OnBehalfSigner{
+		Signer:      delegate,
+		Account:     address,
+		Kind:        AccountKindMultisig,
+		Signatories: signatories,
+		Threshold:   threshold,
+	}, nil
+}
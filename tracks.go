@@ -0,0 +1,314 @@
+package polkassembly
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BlockTime is the target block time of the relay chains
+const BlockTime = 6 * time.Second
+
+// Block counts used by the runtime track definitions
+const (
+	BlocksPerMinute = 10
+	BlocksPerHour   = 60 * BlocksPerMinute
+	BlocksPerDay    = 24 * BlocksPerHour
+)
+
+// CurveType names the OpenGov curve functions
+type CurveType string
+
+const (
+	CurveLinearDecreasing  CurveType = "LinearDecreasing"
+	CurveSteppedDecreasing CurveType = "SteppedDecreasing"
+	CurveReciprocal        CurveType = "Reciprocal"
+)
+
+// Curve describes an approval or support curve over the decision period.
+// All values are fractions in [0, 1]; x is the elapsed fraction of the
+// decision period.
+type Curve struct {
+	Type CurveType `json:"type"`
+
+	// LinearDecreasing falls from Ceil to Floor over the first Length of the period
+	Length float64 `json:"length,omitempty"`
+	Floor  float64 `json:"floor,omitempty"`
+	Ceil   float64 `json:"ceil,omitempty"`
+
+	// SteppedDecreasing starts at Begin and drops by Step every Period, never below End
+	Begin  float64 `json:"begin,omitempty"`
+	End    float64 `json:"end,omitempty"`
+	Step   float64 `json:"step,omitempty"`
+	Period float64 `json:"period,omitempty"`
+
+	// Reciprocal is bounded by Floor and Ceil and passes through Level at Delay
+	Delay float64 `json:"delay,omitempty"`
+	Level float64 `json:"level,omitempty"`
+}
+
+// LinearCurve mirrors the runtime's Curve::make_linear(length, period, floor, ceil)
+// with floor and ceil given in percent
+func LinearCurve(length, period int, floor, ceil float64) Curve {
+	return Curve{
+		Type:   CurveLinearDecreasing,
+		Length: float64(length) / float64(period),
+		Floor:  floor / 100,
+		Ceil:   ceil / 100,
+	}
+}
+
+// SteppedCurve describes a SteppedDecreasing curve with values given in percent
+func SteppedCurve(begin, end, step, period float64) Curve {
+	return Curve{
+		Type:   CurveSteppedDecreasing,
+		Begin:  begin / 100,
+		End:    end / 100,
+		Step:   step / 100,
+		Period: period / 100,
+	}
+}
+
+// ReciprocalCurve mirrors the runtime's Curve::make_reciprocal(delay, period, level, floor, ceil)
+// with level, floor and ceil given in percent
+func ReciprocalCurve(delay, period int, level, floor, ceil float64) Curve {
+	return Curve{
+		Type:  CurveReciprocal,
+		Delay: float64(delay) / float64(period),
+		Level: level / 100,
+		Floor: floor / 100,
+		Ceil:  ceil / 100,
+	}
+}
+
+// Track is an OpenGov referendum track. Periods are in blocks and the
+// decision deposit is in the network's smallest unit.
+type Track struct {
+	ID                 int    `json:"id"`
+	Name               string `json:"name"`
+	Origin             string `json:"origin"`
+	MaxDeciding        int    `json:"maxDeciding"`
+	DecisionDeposit    string `json:"decisionDeposit"`
+	PreparePeriod      int    `json:"preparePeriod"`
+	DecisionPeriod     int    `json:"decisionPeriod"`
+	ConfirmPeriod      int    `json:"confirmPeriod"`
+	MinEnactmentPeriod int    `json:"minEnactmentPeriod"`
+	MinApproval        Curve  `json:"minApproval"`
+	MinSupport         Curve  `json:"minSupport"`
+}
+
+// TrackTimeline holds the earliest milestones of a referendum on a track
+type TrackTimeline struct {
+	SubmittedAt    time.Time `json:"submittedAt"`
+	PrepareEndsAt  time.Time `json:"prepareEndsAt"`
+	DecisionEndsAt time.Time `json:"decisionEndsAt"`
+	// Earliest time a referendum entering confirmation at PrepareEndsAt could be approved
+	EarliestApproval  time.Time `json:"earliestApproval"`
+	EarliestEnactment time.Time `json:"earliestEnactment"`
+}
+
+// BlocksToDuration converts a block count into wall-clock time
+func BlocksToDuration(blocks int) time.Duration {
+	return time.Duration(blocks) * BlockTime
+}
+
+// DisplayName renders the track name in title case, e.g. "Medium Spender"
+func (t Track) DisplayName() string {
+	parts := strings.Split(t.Name, "_")
+	for i, p := range parts {
+		if p != "" {
+			parts[i] = strings.ToUpper(p[:1]) + p[1:]
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func (t Track) PrepareDuration() time.Duration {
+	return BlocksToDuration(t.PreparePeriod)
+}
+
+func (t Track) DecisionDuration() time.Duration {
+	return BlocksToDuration(t.DecisionPeriod)
+}
+
+func (t Track) ConfirmDuration() time.Duration {
+	return BlocksToDuration(t.ConfirmPeriod)
+}
+
+func (t Track) MinEnactmentDuration() time.Duration {
+	return BlocksToDuration(t.MinEnactmentPeriod)
+}
+
+// Timeline computes the track milestones for a referendum submitted at submittedAt
+func (t Track) Timeline(submittedAt time.Time) TrackTimeline {
+	prepareEnds := submittedAt.Add(t.PrepareDuration())
+	approval := prepareEnds.Add(t.ConfirmDuration())
+
+	return TrackTimeline{
+		SubmittedAt:       submittedAt,
+		PrepareEndsAt:     prepareEnds,
+		DecisionEndsAt:    prepareEnds.Add(t.DecisionDuration()),
+		EarliestApproval:  approval,
+		EarliestEnactment: approval.Add(t.MinEnactmentDuration()),
+	}
+}
+
+var (
+	tracksMu sync.RWMutex
+	tracks   = map[string][]Track{
+		"polkadot": polkadotTracks(),
+		"kusama":   kusamaTracks(),
+	}
+)
+
+// RegisterTracks sets the track registry for network, replacing any existing entry
+func RegisterTracks(network string, list []Track) {
+	sorted := append([]Track(nil), list...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	tracksMu.Lock()
+	defer tracksMu.Unlock()
+	tracks[network] = sorted
+}
+
+// Tracks returns the registered tracks of network ordered by ID
+func Tracks(network string) []Track {
+	tracksMu.RLock()
+	defer tracksMu.RUnlock()
+	return append([]Track(nil), tracks[network]...)
+}
+
+// TrackByID looks up a track by its numeric ID
+func TrackByID(network string, id int) (*Track, error) {
+	for _, t := range Tracks(network) {
+		if t.ID == id {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown track %d on %s", id, network)
+}
+
+// TrackByOrigin looks up a track by origin ("MediumSpender"), runtime name
+// ("medium_spender") or display name ("Medium Spender"), ignoring case
+func TrackByOrigin(network string, origin string) (*Track, error) {
+	key := normalizeTrackName(origin)
+	for _, t := range Tracks(network) {
+		if normalizeTrackName(t.Origin) == key || normalizeTrackName(t.Name) == key {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown origin %q on %s", origin, network)
+}
+
+// TrackName returns the display name of a track ID, or "Track N" if unknown
+func TrackName(network string, id int) string {
+	if t, err := TrackByID(network, id); err == nil {
+		return t.DisplayName()
+	}
+	return fmt.Sprintf("Track %d", id)
+}
+
+// Tracks returns the tracks of the client's network
+func (c *Client) Tracks() []Track {
+	return Tracks(c.network)
+}
+
+func planck(amount int64) string {
+	return strconv.FormatInt(amount, 10)
+}
+
+func normalizeTrackName(s string) string {
+	s = strings.ToLower(s)
+	s = strings.ReplaceAll(s, "_", "")
+	s = strings.ReplaceAll(s, " ", "")
+	return s
+}
+
+// Track parameters mirror the polkadot and kusama runtime governance/tracks.rs
+
+func polkadotTracks() []Track {
+	const (
+		dollars = 10_000_000_000 // 10^10 planck
+		grand   = 1000 * dollars
+	)
+
+	return []Track{
+		{0, "root", "Root", 1, planck(100 * grand), 2 * BlocksPerHour, 28 * BlocksPerDay, 24 * BlocksPerHour, 24 * BlocksPerHour,
+			ReciprocalCurve(4, 28, 80, 50, 100), LinearCurve(28, 28, 0, 50)},
+		{1, "whitelisted_caller", "WhitelistedCaller", 100, planck(10 * grand), 30 * BlocksPerMinute, 28 * BlocksPerDay, 10 * BlocksPerMinute, 10 * BlocksPerMinute,
+			ReciprocalCurve(16, 28*24, 96, 50, 100), ReciprocalCurve(1, 28, 20, 5, 50)},
+		{2, "wish_for_change", "WishForChange", 10, planck(20 * grand), 2 * BlocksPerHour, 28 * BlocksPerDay, 24 * BlocksPerHour, 10 * BlocksPerMinute,
+			ReciprocalCurve(4, 28, 80, 50, 100), LinearCurve(28, 28, 0, 50)},
+		{10, "staking_admin", "StakingAdmin", 10, planck(5 * grand), 2 * BlocksPerHour, 28 * BlocksPerDay, 3 * BlocksPerHour, 10 * BlocksPerMinute,
+			LinearCurve(17, 28, 50, 100), ReciprocalCurve(12, 28, 1, 0, 50)},
+		{11, "treasurer", "Treasurer", 10, planck(1 * grand), 2 * BlocksPerHour, 28 * BlocksPerDay, 7 * BlocksPerDay, 24 * BlocksPerHour,
+			ReciprocalCurve(4, 28, 80, 50, 100), LinearCurve(28, 28, 0, 50)},
+		{12, "lease_admin", "LeaseAdmin", 10, planck(5 * grand), 2 * BlocksPerHour, 28 * BlocksPerDay, 3 * BlocksPerHour, 10 * BlocksPerMinute,
+			LinearCurve(17, 28, 50, 100), ReciprocalCurve(12, 28, 1, 0, 50)},
+		{13, "fellowship_admin", "FellowshipAdmin", 10, planck(5 * grand), 2 * BlocksPerHour, 28 * BlocksPerDay, 3 * BlocksPerHour, 10 * BlocksPerMinute,
+			LinearCurve(17, 28, 50, 100), ReciprocalCurve(12, 28, 1, 0, 50)},
+		{14, "general_admin", "GeneralAdmin", 10, planck(5 * grand), 2 * BlocksPerHour, 28 * BlocksPerDay, 3 * BlocksPerHour, 10 * BlocksPerMinute,
+			ReciprocalCurve(4, 28, 80, 50, 100), ReciprocalCurve(7, 28, 10, 0, 50)},
+		{15, "auction_admin", "AuctionAdmin", 10, planck(5 * grand), 2 * BlocksPerHour, 28 * BlocksPerDay, 3 * BlocksPerHour, 10 * BlocksPerMinute,
+			ReciprocalCurve(4, 28, 80, 50, 100), ReciprocalCurve(7, 28, 10, 0, 50)},
+		{20, "referendum_canceller", "ReferendumCanceller", 1000, planck(10 * grand), 2 * BlocksPerHour, 7 * BlocksPerDay, 3 * BlocksPerHour, 10 * BlocksPerMinute,
+			LinearCurve(17, 28, 50, 100), ReciprocalCurve(12, 28, 1, 0, 50)},
+		{21, "referendum_killer", "ReferendumKiller", 1000, planck(50 * grand), 2 * BlocksPerHour, 28 * BlocksPerDay, 3 * BlocksPerHour, 10 * BlocksPerMinute,
+			LinearCurve(17, 28, 50, 100), ReciprocalCurve(12, 28, 1, 0, 50)},
+		{30, "small_tipper", "SmallTipper", 200, planck(1 * dollars), 1 * BlocksPerMinute, 7 * BlocksPerDay, 10 * BlocksPerMinute, 1 * BlocksPerMinute,
+			LinearCurve(10, 28, 50, 100), ReciprocalCurve(1, 28, 4, 0, 50)},
+		{31, "big_tipper", "BigTipper", 100, planck(10 * dollars), 10 * BlocksPerMinute, 7 * BlocksPerDay, 1 * BlocksPerHour, 10 * BlocksPerMinute,
+			LinearCurve(10, 28, 50, 100), ReciprocalCurve(8, 28, 1, 0, 50)},
+		{32, "small_spender", "SmallSpender", 50, planck(100 * dollars), 4 * BlocksPerHour, 28 * BlocksPerDay, 12 * BlocksPerHour, 24 * BlocksPerHour,
+			LinearCurve(17, 28, 50, 100), ReciprocalCurve(12, 28, 1, 0, 50)},
+		{33, "medium_spender", "MediumSpender", 50, planck(200 * dollars), 4 * BlocksPerHour, 28 * BlocksPerDay, 24 * BlocksPerHour, 24 * BlocksPerHour,
+			LinearCurve(23, 28, 50, 100), ReciprocalCurve(16, 28, 1, 0, 50)},
+		{34, "big_spender", "BigSpender", 50, planck(400 * dollars), 4 * BlocksPerHour, 28 * BlocksPerDay, 48 * BlocksPerHour, 24 * BlocksPerHour,
+			LinearCurve(28, 28, 50, 100), ReciprocalCurve(20, 28, 1, 0, 50)},
+	}
+}
+
+func kusamaTracks() []Track {
+	const (
+		quid  = 1_000_000_000_000 / 30
+		grand = 1000 * quid
+	)
+
+	return []Track{
+		{0, "root", "Root", 1, planck(100 * grand), 2 * BlocksPerHour, 14 * BlocksPerDay, 24 * BlocksPerHour, 24 * BlocksPerHour,
+			ReciprocalCurve(4, 14, 80, 50, 100), LinearCurve(14, 14, 0, 50)},
+		{1, "whitelisted_caller", "WhitelistedCaller", 100, planck(10 * grand), 30 * BlocksPerMinute, 14 * BlocksPerDay, 10 * BlocksPerMinute, 10 * BlocksPerMinute,
+			ReciprocalCurve(16, 14*24, 96, 50, 100), ReciprocalCurve(1, 14*24, 1, 0, 2)},
+		{2, "wish_for_change", "WishForChange", 10, planck(20 * grand), 2 * BlocksPerHour, 14 * BlocksPerDay, 24 * BlocksPerHour, 10 * BlocksPerMinute,
+			ReciprocalCurve(4, 14, 80, 50, 100), LinearCurve(14, 14, 0, 50)},
+		{10, "staking_admin", "StakingAdmin", 10, planck(5 * grand), 2 * BlocksPerHour, 14 * BlocksPerDay, 3 * BlocksPerHour, 10 * BlocksPerMinute,
+			LinearCurve(8, 14, 50, 100), ReciprocalCurve(8, 14, 1, 0, 10)},
+		{11, "treasurer", "Treasurer", 10, planck(1 * grand), 2 * BlocksPerHour, 14 * BlocksPerDay, 3 * BlocksPerHour, 24 * BlocksPerHour,
+			ReciprocalCurve(4, 14, 80, 50, 100), LinearCurve(14, 14, 0, 50)},
+		{12, "lease_admin", "LeaseAdmin", 10, planck(5 * grand), 2 * BlocksPerHour, 14 * BlocksPerDay, 3 * BlocksPerHour, 10 * BlocksPerMinute,
+			LinearCurve(8, 14, 50, 100), ReciprocalCurve(8, 14, 1, 0, 10)},
+		{13, "fellowship_admin", "FellowshipAdmin", 10, planck(5 * grand), 2 * BlocksPerHour, 14 * BlocksPerDay, 3 * BlocksPerHour, 10 * BlocksPerMinute,
+			LinearCurve(8, 14, 50, 100), ReciprocalCurve(8, 14, 1, 0, 10)},
+		{14, "general_admin", "GeneralAdmin", 10, planck(5 * grand), 2 * BlocksPerHour, 14 * BlocksPerDay, 3 * BlocksPerHour, 10 * BlocksPerMinute,
+			ReciprocalCurve(4, 14, 80, 50, 100), ReciprocalCurve(7, 14, 10, 0, 50)},
+		{15, "auction_admin", "AuctionAdmin", 10, planck(5 * grand), 2 * BlocksPerHour, 14 * BlocksPerDay, 3 * BlocksPerHour, 10 * BlocksPerMinute,
+			ReciprocalCurve(4, 14, 80, 50, 100), ReciprocalCurve(7, 14, 10, 0, 50)},
+		{20, "referendum_canceller", "ReferendumCanceller", 1000, planck(10 * grand), 2 * BlocksPerHour, 7 * BlocksPerDay, 3 * BlocksPerHour, 10 * BlocksPerMinute,
+			LinearCurve(7, 7, 50, 100), ReciprocalCurve(1, 7, 1, 0, 10)},
+		{21, "referendum_killer", "ReferendumKiller", 1000, planck(50 * grand), 2 * BlocksPerHour, 14 * BlocksPerDay, 3 * BlocksPerHour, 10 * BlocksPerMinute,
+			LinearCurve(14, 14, 50, 100), ReciprocalCurve(1, 14, 1, 0, 10)},
+		{30, "small_tipper", "SmallTipper", 200, planck(1 * quid), 1 * BlocksPerMinute, 7 * BlocksPerDay, 10 * BlocksPerMinute, 1 * BlocksPerMinute,
+			LinearCurve(10, 28, 50, 100), ReciprocalCurve(1, 28, 4, 0, 50)},
+		{31, "big_tipper", "BigTipper", 100, planck(10 * quid), 10 * BlocksPerMinute, 7 * BlocksPerDay, 1 * BlocksPerHour, 10 * BlocksPerMinute,
+			LinearCurve(10, 28, 50, 100), ReciprocalCurve(8, 28, 1, 0, 50)},
+		{32, "small_spender", "SmallSpender", 50, planck(100 * quid), 4 * BlocksPerHour, 14 * BlocksPerDay, 12 * BlocksPerHour, 24 * BlocksPerHour,
+			LinearCurve(17, 28, 50, 100), ReciprocalCurve(12, 28, 1, 0, 50)},
+		{33, "medium_spender", "MediumSpender", 50, planck(200 * quid), 4 * BlocksPerHour, 14 * BlocksPerDay, 24 * BlocksPerHour, 24 * BlocksPerHour,
+			LinearCurve(23, 28, 50, 100), ReciprocalCurve(16, 28, 1, 0, 50)},
+		{34, "big_spender", "BigSpender", 50, planck(400 * quid), 4 * BlocksPerHour, 14 * BlocksPerDay, 48 * BlocksPerHour, 24 * BlocksPerHour,
+			LinearCurve(28, 28, 50, 100), ReciprocalCurve(20, 28, 1, 0, 50)},
+	}
+}
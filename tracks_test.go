@@ -0,0 +1,37 @@
+package polkassembly
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTrackRegistry(t *testing.T) {
+	track, err := TrackByOrigin("polkadot", "medium_spender")
+	if err != nil {
+		t.Fatalf("TrackByOrigin failed: %v", err)
+	}
+	if track.ID != 33 || track.Origin != "MediumSpender" {
+		t.Errorf("unexpected track: %d %s", track.ID, track.Origin)
+	}
+	if track.DisplayName() != "Medium Spender" {
+		t.Errorf("unexpected display name: %s", track.DisplayName())
+	}
+
+	if name := TrackName("polkadot", 0); name != "Root" {
+		t.Errorf("expected Root, got %s", name)
+	}
+
+	root, err := TrackByID("kusama", 0)
+	if err != nil {
+		t.Fatalf("TrackByID failed: %v", err)
+	}
+	if root.DecisionDuration() != 14*24*time.Hour {
+		t.Errorf("unexpected kusama root decision period: %s", root.DecisionDuration())
+	}
+
+	submitted := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeline := track.Timeline(submitted)
+	if !timeline.DecisionEndsAt.Equal(submitted.Add(4*time.Hour + 28*24*time.Hour)) {
+		t.Errorf("unexpected decision end: %s", timeline.DecisionEndsAt)
+	}
+}
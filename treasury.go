@@ -0,0 +1,283 @@
+package polkassembly
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GetTreasuryProposals lists treasury proposals by status and proposer
+func (c *Client) GetTreasuryProposals(params TreasuryListingParams) ([]TreasuryProposal, error) {
+	r, err := c.client.R().
+		SetQueryParams(treasuryQueryParams(params.Page, params.Limit, params.Status, params.Proposer, "")).
+		Get(fmt.Sprintf("/%s", ProposalTypeTreasuryProposal.PathSegment()))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []TreasuryProposal
+	if err := c.parseListResponse(r, "proposals", &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetTreasuryProposal retrieves a treasury proposal by index
+func (c *Client) GetTreasuryProposal(proposalID int) (*TreasuryProposal, error) {
+	r, err := c.client.R().
+		Get(fmt.Sprintf("/%s/%d", ProposalTypeTreasuryProposal.PathSegment(), proposalID))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp TreasuryProposal
+	if err := c.parseResponse(r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CreateTreasuryProposal creates the post for a treasury proposal
+func (c *Client) CreateTreasuryProposal(req CreateTreasuryProposalRequest) (*TreasuryProposal, error) {
+	r, err := c.client.R().
+		SetBody(req).
+		Post(fmt.Sprintf("/%s", ProposalTypeTreasuryProposal.PathSegment()))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp TreasuryProposal
+	if err := c.parseResponse(r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateTreasuryProposal edits the title, content and tags of a treasury proposal post
+func (c *Client) UpdateTreasuryProposal(proposalID int, req UpdatePostRequest) (*Post, error) {
+	return c.UpdatePost(ProposalTypeTreasuryProposal, proposalID, req)
+}
+
+// GetTips lists tips by status and finder
+func (c *Client) GetTips(params TreasuryListingParams) ([]Tip, error) {
+	r, err := c.client.R().
+		SetQueryParams(treasuryQueryParams(params.Page, params.Limit, params.Status, params.Proposer, "")).
+		Get(fmt.Sprintf("/%s", ProposalTypeTip.PathSegment()))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []Tip
+	if err := c.parseListResponse(r, "tips", &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetTip retrieves a tip by hash
+func (c *Client) GetTip(hash string) (*Tip, error) {
+	r, err := c.client.R().
+		Get(fmt.Sprintf("/%s/%s", ProposalTypeTip.PathSegment(), hash))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp Tip
+	if err := c.parseResponse(r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// CreateTip creates the post for a tip
+func (c *Client) CreateTip(req CreateTipRequest) (*Tip, error) {
+	r, err := c.client.R().
+		SetBody(req).
+		Post(fmt.Sprintf("/%s", ProposalTypeTip.PathSegment()))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp Tip
+	if err := c.parseResponse(r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateTip edits the title, content and tags of a tip post
+func (c *Client) UpdateTip(hash string, req UpdatePostRequest) (*Post, error) {
+	return c.patchPost(fmt.Sprintf("/%s/%s", ProposalTypeTip.PathSegment(), hash), req)
+}
+
+// GetBounties lists bounties by status, proposer and curator
+func (c *Client) GetBounties(params BountyListingParams) ([]Bounty, error) {
+	r, err := c.client.R().
+		SetQueryParams(treasuryQueryParams(params.Page, params.Limit, params.Status, params.Proposer, params.Curator)).
+		Get(fmt.Sprintf("/%s", ProposalTypeBounty.PathSegment()))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []Bounty
+	if err := c.parseListResponse(r, "bounties", &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetBounty retrieves a bounty by index
+func (c *Client) GetBounty(bountyID int) (*Bounty, error) {
+	return c.getBounty(ProposalTypeBounty, bountyID)
+}
+
+// CreateBounty creates the post for a bounty
+func (c *Client) CreateBounty(req CreateBountyRequest) (*Bounty, error) {
+	req.ParentBountyID = 0
+	return c.createBounty(ProposalTypeBounty, req)
+}
+
+// UpdateBounty edits the title, content and tags of a bounty post
+func (c *Client) UpdateBounty(bountyID int, req UpdatePostRequest) (*Post, error) {
+	return c.UpdatePost(ProposalTypeBounty, bountyID, req)
+}
+
+// GetChildBounties retrieves child bounties for a parent bounty
+func (c *Client) GetChildBounties(bountyID int) ([]Bounty, error) {
+	return c.ListChildBounties(bountyID, BountyListingParams{})
+}
+
+// ListChildBounties lists the child bounties of a parent bounty by status,
+// proposer and curator
+func (c *Client) ListChildBounties(bountyID int, params BountyListingParams) ([]Bounty, error) {
+	r, err := c.client.R().
+		SetQueryParams(treasuryQueryParams(params.Page, params.Limit, params.Status, params.Proposer, params.Curator)).
+		Get(fmt.Sprintf("/%s/%d/child-bounties", ProposalTypeBounty.PathSegment(), bountyID))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []Bounty
+	if err := c.parseListResponse(r, "child_bounties", &resp); err != nil {
+		return nil, err
+	}
+	for i := range resp {
+		if resp[i].ParentBountyID == 0 {
+			resp[i].ParentBountyID = bountyID
+		}
+	}
+	return resp, nil
+}
+
+// GetChildBounty retrieves a child bounty by index
+func (c *Client) GetChildBounty(childBountyID int) (*Bounty, error) {
+	return c.getBounty(ProposalTypeChildBounty, childBountyID)
+}
+
+// CreateChildBounty creates the post for a child bounty of parentBountyID
+func (c *Client) CreateChildBounty(parentBountyID int, req CreateBountyRequest) (*Bounty, error) {
+	req.ParentBountyID = parentBountyID
+	return c.createBounty(ProposalTypeChildBounty, req)
+}
+
+// UpdateChildBounty edits the title, content and tags of a child bounty post
+func (c *Client) UpdateChildBounty(childBountyID int, req UpdatePostRequest) (*Post, error) {
+	return c.UpdatePost(ProposalTypeChildBounty, childBountyID, req)
+}
+
+func (c *Client) getBounty(proposalType ProposalType, bountyID int) (*Bounty, error) {
+	r, err := c.client.R().
+		Get(fmt.Sprintf("/%s/%d", proposalType.PathSegment(), bountyID))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp Bounty
+	if err := c.parseResponse(r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *Client) createBounty(proposalType ProposalType, req CreateBountyRequest) (*Bounty, error) {
+	r, err := c.client.R().
+		SetBody(req).
+		Post(fmt.Sprintf("/%s", proposalType.PathSegment()))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp Bounty
+	if err := c.parseResponse(r, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// FilterTreasuryProposals applies the status and proposer filters of params
+func FilterTreasuryProposals(proposals []TreasuryProposal, params TreasuryListingParams) []TreasuryProposal {
+	var filtered []TreasuryProposal
+	for _, p := range proposals {
+		if matchesTreasuryFilter(p.Status, params.Status, p.Proposer, params.Proposer) {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
+
+// FilterTips applies the status and finder filters of params
+func FilterTips(tips []Tip, params TreasuryListingParams) []Tip {
+	var filtered []Tip
+	for _, t := range tips {
+		if matchesTreasuryFilter(t.Status, params.Status, t.Finder, params.Proposer) {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
+
+// FilterBounties applies the status, proposer and curator filters of params
+func FilterBounties(bounties []Bounty, params BountyListingParams) []Bounty {
+	var filtered []Bounty
+	for _, b := range bounties {
+		if !matchesTreasuryFilter(b.Status, params.Status, b.Proposer, params.Proposer) {
+			continue
+		}
+		if params.Curator != "" && !SameAccount(b.Curator, params.Curator) {
+			continue
+		}
+		filtered = append(filtered, b)
+	}
+	return filtered
+}
+
+// matchesTreasuryFilter compares statuses case-insensitively and accounts
+// across SS58 prefixes. Empty filters match everything.
+func matchesTreasuryFilter(status, wantStatus, account, wantAccount string) bool {
+	if wantStatus != "" && !strings.EqualFold(status, wantStatus) {
+		return false
+	}
+	if wantAccount != "" && !SameAccount(account, wantAccount) {
+		return false
+	}
+	return true
+}
+
+func treasuryQueryParams(page, limit int, status, proposer, curator string) map[string]string {
+	queryParams := make(map[string]string)
+	if page > 0 {
+		queryParams["page"] = fmt.Sprintf("%d", page)
+	}
+	if limit > 0 {
+		queryParams["limit"] = fmt.Sprintf("%d", limit)
+	}
+	if status != "" {
+		queryParams["status"] = status
+	}
+	if proposer != "" {
+		queryParams["proposer"] = proposer
+	}
+	if curator != "" {
+		queryParams["curator"] = curator
+	}
+	return queryParams
+}
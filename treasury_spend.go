@@ -0,0 +1,474 @@
+package polkassembly
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/polkadot-go/polkassembly-api/scale"
+)
+
+// PriceSource prices assets in a reference currency
+type PriceSource interface {
+	// Price returns the price of one whole token of symbol at the given time
+	Price(symbol string, at time.Time) (float64, error)
+}
+
+// StaticPrices is a PriceSource with fixed prices by symbol, for offline use
+type StaticPrices map[string]float64
+
+func (p StaticPrices) Price(symbol string, _ time.Time) (float64, error) {
+	price, ok := p[strings.ToUpper(symbol)]
+	if !ok {
+		return 0, fmt.Errorf("no price for %s", symbol)
+	}
+	return price, nil
+}
+
+// SpendPeriod groups spends by calendar period
+type SpendPeriod string
+
+const (
+	SpendPeriodMonth   SpendPeriod = "month"
+	SpendPeriodQuarter SpendPeriod = "quarter"
+	SpendPeriodYear    SpendPeriod = "year"
+)
+
+// Key returns the period containing t, e.g. "2024-03", "2024-Q1" or "2024"
+func (p SpendPeriod) Key(t time.Time) string {
+	t = t.UTC()
+	switch p {
+	case SpendPeriodQuarter:
+		return fmt.Sprintf("%d-Q%d", t.Year(), (int(t.Month())-1)/3+1)
+	case SpendPeriodYear:
+		return fmt.Sprintf("%d", t.Year())
+	default:
+		return t.Format("2006-01")
+	}
+}
+
+// TreasurySpend is an amount a referendum requests for one beneficiary
+type TreasurySpend struct {
+	PostID      int       `json:"postId"`
+	TrackNo     int       `json:"trackNo"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"createdAt"`
+	Beneficiary string    `json:"beneficiary"`
+	AssetID     string    `json:"assetId,omitempty"`
+	Symbol      string    `json:"symbol"`
+	Decimals    int       `json:"decimals"`
+	// Amount is in the smallest unit of the asset, Units in whole tokens
+	Amount string  `json:"amount"`
+	Units  string  `json:"units"`
+	Value  float64 `json:"value,omitempty"`
+	Priced bool    `json:"priced"`
+	// AssetError is set when the asset is not in the registry; Symbol,
+	// Decimals and Units are then empty and the spend is never priced
+	AssetError string `json:"assetError,omitempty"`
+}
+
+// ExtractSpends lists the spends requested by a referendum from its on-chain
+// beneficiaries. Spends of assets missing from the registry (see
+// RegisterAsset) are kept with their AssetError set.
+func ExtractSpends(post *Post, network string) ([]TreasurySpend, error) {
+	if post.OnChainInfo == nil {
+		return nil, nil
+	}
+
+	var spends []TreasurySpend
+	for _, b := range post.OnChainInfo.Beneficiaries {
+		spend, err := newTreasurySpend(post, network, b.Address, b.AssetID, b.Amount)
+		if err != nil {
+			return nil, err
+		}
+		spends = append(spends, spend)
+	}
+	return spends, nil
+}
+
+// SpendsFromCall lists the treasury.spend and treasury.spendLocal calls in a
+// decoded call tree, for referenda whose beneficiaries are not indexed. A
+// treasury.spend asset is identified by its GeneralIndex, or is the native
+// token when it is the relay chain itself; any other asset is unknown.
+func SpendsFromCall(post *Post, network string, call *DecodedCall) ([]TreasurySpend, error) {
+	var spends []TreasurySpend
+	var err error
+	call.Walk(func(c *DecodedCall) {
+		if err != nil || !scale.SameName(c.Pallet, "treasury") {
+			return
+		}
+
+		var assetID string
+		var assetErr error
+		switch {
+		case scale.SameName(c.Method, "spendLocal"):
+		case scale.SameName(c.Method, "spend"):
+			kind, _ := c.Arg("asset_kind")
+			if index, ok := findNamed(kind, "GeneralIndex"); ok {
+				assetID = plainNumber(index)
+			} else if !relayNativeAsset(kind) {
+				assetErr = fmt.Errorf("%w: asset kind is neither a GeneralIndex nor the relay chain native token", ErrUnknownAsset)
+			}
+		default:
+			return
+		}
+
+		amount, _ := c.Arg("amount")
+		beneficiary, _ := c.Arg("beneficiary")
+
+		spend, e := newTreasurySpend(post, network, beneficiaryAddress(beneficiary), assetID, plainNumber(amount))
+		if e != nil {
+			err = e
+			return
+		}
+		if assetErr != nil {
+			spend.setUnknownAsset(assetErr)
+		}
+		spends = append(spends, spend)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return spends, nil
+}
+
+func newTreasurySpend(post *Post, network, beneficiary, assetID, amount string) (TreasurySpend, error) {
+	value, err := ParseBalance(amount)
+	if err != nil {
+		return TreasurySpend{}, fmt.Errorf("referendum %d: %w", post.Index, err)
+	}
+
+	spend := TreasurySpend{
+		PostID:      post.Index,
+		TrackNo:     post.TrackNumber,
+		Status:      post.Status,
+		CreatedAt:   post.CreatedAt,
+		Beneficiary: beneficiary,
+		AssetID:     strings.TrimSpace(assetID),
+		Amount:      value.String(),
+	}
+	if asset, err := AssetByID(network, assetID); err == nil {
+		spend.Symbol = asset.Symbol
+		spend.Decimals = asset.Decimals
+		spend.Units = FormatUnits(value, asset.Decimals)
+	} else {
+		spend.setUnknownAsset(err)
+	}
+	if post.OnChainInfo != nil {
+		if post.OnChainInfo.Status != "" {
+			spend.Status = post.OnChainInfo.Status
+		}
+		if !post.OnChainInfo.CreatedAt.IsZero() {
+			spend.CreatedAt = post.OnChainInfo.CreatedAt
+		}
+	}
+	if track, err := postTrack(post, network); err == nil {
+		spend.TrackNo = track.ID
+	}
+	return spend, nil
+}
+
+// setUnknownAsset marks the asset of the spend as unknown because of err
+func (s *TreasurySpend) setUnknownAsset(err error) {
+	s.Symbol, s.Decimals, s.Units = "", 0, ""
+	s.AssetError = err.Error()
+}
+
+// assetKey groups spends of the same asset, by symbol when it is known
+func (s TreasurySpend) assetKey() string {
+	if s.AssetError == "" {
+		return s.Symbol
+	}
+	if s.AssetID != "" {
+		return "asset " + s.AssetID
+	}
+	return "unknown asset"
+}
+
+// relayNativeAsset reports whether a treasury.spend asset kind is the native
+// token of the relay chain: an asset_id location with no interior junctions,
+// i.e. Here
+func relayNativeAsset(kind interface{}) bool {
+	assetID, ok := findNamed(kind, "asset_id")
+	if !ok {
+		return false
+	}
+	interior, ok := findNamed(assetID, "interior")
+	if !ok {
+		return false
+	}
+	here, ok := interior.(EnumValue)
+	return ok && scale.SameName(here.Variant, "Here")
+}
+
+// findNamed searches a decoded value for an enum variant or field called name
+func findNamed(v interface{}, name string) (interface{}, bool) {
+	switch x := v.(type) {
+	case EnumValue:
+		if scale.SameName(x.Variant, name) {
+			return x.Value, true
+		}
+		return findNamed(x.Value, name)
+	case []DecodedArg:
+		for _, f := range x {
+			if scale.SameName(f.Name, name) {
+				return f.Value, true
+			}
+			if found, ok := findNamed(f.Value, name); ok {
+				return found, true
+			}
+		}
+	case []interface{}:
+		for _, item := range x {
+			if found, ok := findNamed(item, name); ok {
+				return found, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// beneficiaryAddress finds the account of a MultiAddress or XCM location
+func beneficiaryAddress(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	if id, ok := findNamed(v, "Id"); ok {
+		if s, ok := id.(string); ok {
+			return s
+		}
+	}
+	if junction, ok := findNamed(v, "AccountId32"); ok {
+		if id, ok := findNamed(junction, "id"); ok {
+			if s, ok := id.(string); ok {
+				return s
+			}
+		}
+		if s, ok := junction.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
+// plainNumber formats a decoded number, dropping the thousands separators
+// used by calls the API decoded to JSON
+func plainNumber(v interface{}) string {
+	switch x := v.(type) {
+	case *big.Int:
+		return x.String()
+	case string:
+		return strings.ReplaceAll(x, ",", "")
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+type TreasuryAnalysisOptions struct {
+	// Prices values spends; without it spends are only totalled per asset
+	Prices PriceSource
+	// Currency labels the reference currency of Prices (default "USD")
+	Currency string
+	// Period groups spends in ByPeriod (default month)
+	Period SpendPeriod
+}
+
+// AssetTotal sums the spends of one asset
+type AssetTotal struct {
+	Symbol string  `json:"symbol"`
+	Amount string  `json:"amount"`
+	Units  string  `json:"units"`
+	Value  float64 `json:"value,omitempty"`
+}
+
+// SpendSummary totals a group of spends. Value only includes priced spends.
+type SpendSummary struct {
+	Referenda int                    `json:"referenda"`
+	Spends    int                    `json:"spends"`
+	Value     float64                `json:"value"`
+	Unpriced  int                    `json:"unpriced"`
+	Assets    map[string]*AssetTotal `json:"assets"`
+}
+
+// TreasuryAnalysis aggregates the spends requested by referenda
+type TreasuryAnalysis struct {
+	Currency string                   `json:"currency"`
+	Spends   []TreasurySpend          `json:"spends"`
+	Total    *SpendSummary            `json:"total"`
+	ByTrack  map[int]*SpendSummary    `json:"byTrack"`
+	ByPeriod map[string]*SpendSummary `json:"byPeriod"`
+}
+
+// AnalyzeSpends values spends with opts.Prices at their creation time and
+// totals them overall, per track and per period
+func AnalyzeSpends(spends []TreasurySpend, opts TreasuryAnalysisOptions) (*TreasuryAnalysis, error) {
+	if opts.Currency == "" {
+		opts.Currency = "USD"
+	}
+	if opts.Period == "" {
+		opts.Period = SpendPeriodMonth
+	}
+
+	total := newSpendAcc()
+	byTrack := make(map[int]*spendAcc)
+	byPeriod := make(map[string]*spendAcc)
+
+	valued := make([]TreasurySpend, len(spends))
+	for i, s := range spends {
+		if opts.Prices != nil && s.AssetError == "" {
+			if price, err := opts.Prices.Price(s.Symbol, s.CreatedAt); err == nil {
+				amount, err := ParseBalance(s.Amount)
+				if err != nil {
+					return nil, err
+				}
+				s.Value = toUnits(amount, s.Decimals) * price
+				s.Priced = true
+			}
+		}
+		valued[i] = s
+
+		if byTrack[s.TrackNo] == nil {
+			byTrack[s.TrackNo] = newSpendAcc()
+		}
+		period := opts.Period.Key(s.CreatedAt)
+		if byPeriod[period] == nil {
+			byPeriod[period] = newSpendAcc()
+		}
+		for _, acc := range []*spendAcc{total, byTrack[s.TrackNo], byPeriod[period]} {
+			if err := acc.add(s); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	analysis := &TreasuryAnalysis{
+		Currency: opts.Currency,
+		Spends:   valued,
+		Total:    total.result(),
+		ByTrack:  make(map[int]*SpendSummary),
+		ByPeriod: make(map[string]*SpendSummary),
+	}
+	for k, acc := range byTrack {
+		analysis.ByTrack[k] = acc.result()
+	}
+	for k, acc := range byPeriod {
+		analysis.ByPeriod[k] = acc.result()
+	}
+	return analysis, nil
+}
+
+// Periods returns the keys of ByPeriod in chronological order
+func (a *TreasuryAnalysis) Periods() []string {
+	keys := make([]string, 0, len(a.ByPeriod))
+	for k := range a.ByPeriod {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// spendAcc accumulates a SpendSummary
+type spendAcc struct {
+	referenda map[int]bool
+	spends    int
+	value     float64
+	unpriced  int
+	amounts   map[string]*big.Int
+	assets    map[string]TreasurySpend
+	values    map[string]float64
+}
+
+func newSpendAcc() *spendAcc {
+	return &spendAcc{
+		referenda: make(map[int]bool),
+		amounts:   make(map[string]*big.Int),
+		assets:    make(map[string]TreasurySpend),
+		values:    make(map[string]float64),
+	}
+}
+
+func (a *spendAcc) add(s TreasurySpend) error {
+	amount, err := ParseBalance(s.Amount)
+	if err != nil {
+		return err
+	}
+
+	a.referenda[s.PostID] = true
+	a.spends++
+	key := s.assetKey()
+	if s.Priced {
+		a.value += s.Value
+		a.values[key] += s.Value
+	} else {
+		a.unpriced++
+	}
+
+	if a.amounts[key] == nil {
+		a.amounts[key] = new(big.Int)
+		a.assets[key] = s
+	}
+	a.amounts[key].Add(a.amounts[key], amount)
+	return nil
+}
+
+func (a *spendAcc) result() *SpendSummary {
+	summary := &SpendSummary{
+		Referenda: len(a.referenda),
+		Spends:    a.spends,
+		Value:     a.value,
+		Unpriced:  a.unpriced,
+		Assets:    make(map[string]*AssetTotal),
+	}
+	for key, amount := range a.amounts {
+		total := &AssetTotal{
+			Symbol: key,
+			Amount: amount.String(),
+			Value:  a.values[key],
+		}
+		if spend := a.assets[key]; spend.AssetError == "" {
+			total.Units = FormatUnits(amount, spend.Decimals)
+		}
+		summary.Assets[key] = total
+	}
+	return summary
+}
+
+// GetTreasurySpends lists the spends requested by a referendum, decoding its
+// preimage when the beneficiaries are not indexed
+func (c *Client) GetTreasurySpends(postID int) ([]TreasurySpend, error) {
+	post, err := c.GetPostByType(postID, ProposalTypeReferendumV2)
+	if err != nil {
+		return nil, err
+	}
+	if post.Index == 0 {
+		post.Index = postID
+	}
+
+	spends, err := ExtractSpends(post, c.network)
+	if err != nil || len(spends) > 0 {
+		return spends, err
+	}
+
+	preimage, err := c.GetPreimageForPost(ProposalTypeReferendumV2, postID)
+	if err != nil || preimage.Call == nil {
+		c.logDebug("No preimage call for referendum %d: %v", postID, err)
+		return nil, nil
+	}
+	return SpendsFromCall(post, c.network, preimage.Call)
+}
+
+// AnalyzeTreasury collects and values the spends requested by referenda
+func (c *Client) AnalyzeTreasury(postIDs []int, opts TreasuryAnalysisOptions) (*TreasuryAnalysis, error) {
+	var spends []TreasurySpend
+	for _, id := range postIDs {
+		s, err := c.GetTreasurySpends(id)
+		if err != nil {
+			return nil, err
+		}
+		spends = append(spends, s...)
+	}
+	return AnalyzeSpends(spends, opts)
+}
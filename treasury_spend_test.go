@@ -0,0 +1,145 @@
+package polkassembly
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnalyzeSpends(t *testing.T) {
+	march := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	may := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+
+	spendPost := func(index int, track int, created time.Time, beneficiaries ...Beneficiary) *Post {
+		return &Post{Index: index, TrackNumber: track, CreatedAt: created, OnChainInfo: &OnChainInfo{Beneficiaries: beneficiaries}}
+	}
+
+	var spends []TreasurySpend
+	for _, post := range []*Post{
+		spendPost(1, 33, march,
+			Beneficiary{Address: aliceAddress, Amount: "15000000000"},
+			Beneficiary{Address: bobAddress, Amount: "2500000", AssetID: "1984"}),
+		spendPost(2, 34, may, Beneficiary{Address: aliceAddress, Amount: "0x2540be400", AssetID: "1337"}),
+	} {
+		s, err := ExtractSpends(post, "polkadot")
+		if err != nil {
+			t.Fatal(err)
+		}
+		spends = append(spends, s...)
+	}
+
+	if len(spends) != 3 || spends[0].Symbol != "DOT" || spends[0].Units != "1.5" || spends[1].Symbol != "USDT" || spends[1].Units != "2.5" {
+		t.Fatalf("unexpected spends: %+v", spends)
+	}
+
+	analysis, err := AnalyzeSpends(spends, TreasuryAnalysisOptions{
+		Prices: StaticPrices{"DOT": 6, "USDT": 1},
+		Period: SpendPeriodQuarter,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 1.5 DOT * 6 + 2.5 USDT; USDC is unpriced
+	if analysis.Total.Value != 11.5 || analysis.Total.Unpriced != 1 || analysis.Total.Referenda != 2 {
+		t.Errorf("unexpected total: %+v", analysis.Total)
+	}
+	if usdc := analysis.Total.Assets["USDC"]; usdc == nil || usdc.Units != "10000" {
+		t.Errorf("unexpected USDC total: %+v", usdc)
+	}
+	if small := analysis.ByTrack[33]; small == nil || small.Spends != 2 || small.Value != 11.5 {
+		t.Errorf("unexpected track 33 summary: %+v", small)
+	}
+	if periods := analysis.Periods(); len(periods) != 2 || periods[0] != "2024-Q1" || periods[1] != "2024-Q2" {
+		t.Errorf("unexpected periods: %v", periods)
+	}
+
+	unknown, err := ExtractSpends(spendPost(3, 33, may,
+		Beneficiary{Address: aliceAddress, Amount: "1", AssetID: "42"},
+		Beneficiary{Address: bobAddress, Amount: "10000000000"}), "polkadot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unknown) != 2 || unknown[0].AssetError == "" || unknown[0].Symbol != "" || unknown[1].Symbol != "DOT" {
+		t.Fatalf("expected the unknown asset recorded on its spend only: %+v", unknown)
+	}
+	analysis, err = AnalyzeSpends(unknown, TreasuryAnalysisOptions{Prices: StaticPrices{"DOT": 6}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if analysis.Total.Value != 6 || analysis.Total.Unpriced != 1 || analysis.Total.Assets["asset 42"] == nil {
+		t.Errorf("unexpected total with an unknown asset: %+v", analysis.Total)
+	}
+}
+
+func TestSpendsFromCall(t *testing.T) {
+	m, err := DefaultMetadata("polkadot")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	local := Call{Pallet: "treasury", Method: "spendLocal", Args: map[string]interface{}{
+		"amount":      "20000000000",
+		"beneficiary": EnumValue{Variant: "Id", Value: bobAddress},
+	}}
+	data, err := EncodeCall(m, BatchAll(local))
+	if err != nil {
+		t.Fatal(err)
+	}
+	call, err := DecodeCall(m, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spends, err := SpendsFromCall(&Post{Index: 9}, "polkadot", call)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spends) != 1 || spends[0].Units != "2" || spends[0].Symbol != "DOT" || !SameAccount(spends[0].Beneficiary, bobAddress) {
+		t.Errorf("unexpected spends: %+v", spends)
+	}
+}
+
+func TestSpendsFromCallAssetKinds(t *testing.T) {
+	location := func(parents int, interior EnumValue) []DecodedArg {
+		return []DecodedArg{{Name: "parents", Value: parents}, {Name: "interior", Value: interior}}
+	}
+	assetHub := location(0, EnumValue{Variant: "X1", Value: []interface{}{EnumValue{Variant: "Parachain", Value: 1000}}})
+	spend := func(assetID []DecodedArg) *DecodedCall {
+		return &DecodedCall{Pallet: "treasury", Method: "spend", Args: []DecodedArg{
+			{Name: "asset_kind", Value: EnumValue{Variant: "V4", Value: []DecodedArg{
+				{Name: "location", Value: assetHub},
+				{Name: "asset_id", Value: assetID},
+			}}},
+			{Name: "amount", Value: "10000000000"},
+			{Name: "beneficiary", Value: bobAddress},
+		}}
+	}
+
+	// DOT as seen from Asset Hub, a foreign asset and an unregistered index
+	dot := spend(location(1, EnumValue{Variant: "Here"}))
+	foreign := spend(location(1, EnumValue{Variant: "X1", Value: []interface{}{EnumValue{Variant: "Parachain", Value: 2030}}}))
+	unregistered := spend(location(0, EnumValue{Variant: "X2", Value: []interface{}{
+		EnumValue{Variant: "PalletInstance", Value: 50},
+		EnumValue{Variant: "GeneralIndex", Value: 42},
+	}}))
+	batch := &DecodedCall{Pallet: "utility", Method: "batchAll", Args: []DecodedArg{
+		{Name: "calls", Value: []interface{}{dot, foreign, unregistered}},
+	}}
+
+	spends, err := SpendsFromCall(&Post{Index: 4}, "polkadot", batch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spends) != 3 {
+		t.Fatalf("expected 3 spends, got %+v", spends)
+	}
+	if spends[0].Symbol != "DOT" || spends[0].Units != "1" || spends[0].AssetError != "" {
+		t.Errorf("expected a native spend, got %+v", spends[0])
+	}
+	if spends[1].Symbol != "" || spends[1].AssetError == "" {
+		t.Errorf("expected an unknown foreign asset, got %+v", spends[1])
+	}
+	if spends[2].AssetID != "42" || spends[2].AssetError == "" {
+		t.Errorf("expected unknown asset 42, got %+v", spends[2])
+	}
+}
@@ -0,0 +1,34 @@
+package polkassembly
+
+import "testing"
+
+func TestFilterBounties(t *testing.T) {
+	bounties := []Bounty{
+		{BountyID: 1, Status: "Active", Proposer: aliceAddress, Curator: bobAddress},
+		{BountyID: 2, Status: "Proposed", Proposer: bobAddress},
+		{BountyID: 3, Status: "active", Proposer: charlieAddress, Curator: charlieAddress},
+	}
+
+	if active := FilterBounties(bounties, BountyListingParams{Status: "ACTIVE"}); len(active) != 2 {
+		t.Errorf("expected 2 active bounties, got %d", len(active))
+	}
+	if curated := FilterBounties(bounties, BountyListingParams{Curator: bobAddress}); len(curated) != 1 || curated[0].BountyID != 1 {
+		t.Errorf("unexpected curator filter result: %+v", curated)
+	}
+	if all := FilterBounties(bounties, BountyListingParams{}); len(all) != 3 {
+		t.Errorf("expected no filtering, got %d bounties", len(all))
+	}
+}
+
+func TestFilterTips(t *testing.T) {
+	tips := []Tip{
+		{Hash: "0x01", Finder: aliceAddress, Status: "Opened"},
+		{Hash: "0x02", Finder: bobAddress, Status: "Closed"},
+	}
+	if found := FilterTips(tips, TreasuryListingParams{Proposer: aliceAddress}); len(found) != 1 || found[0].Hash != "0x01" {
+		t.Errorf("unexpected finder filter result: %+v", found)
+	}
+	if found := FilterTips(tips, TreasuryListingParams{Status: "closed", Proposer: aliceAddress}); len(found) != 0 {
+		t.Errorf("expected no tips, got %+v", found)
+	}
+}
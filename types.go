@@ -17,11 +17,13 @@ func (e *APIError) Error() string {
 
 // Auth types
 type Web3AuthRequest struct {
-	Address   string `json:"address"`
-	Signature string `json:"signature"`
-	Wallet    string `json:"wallet"`
-	Message   string `json:"message,omitempty"`
-	Network   string `json:"network,omitempty"`
+	Address         string `json:"address"`
+	Signature       string `json:"signature"`
+	Wallet          string `json:"wallet"`
+	Message         string `json:"message,omitempty"`
+	Network         string `json:"network,omitempty"`
+	ProxiedAddress  string `json:"proxiedAddress,omitempty"`
+	MultisigAddress string `json:"multisigAddress,omitempty"`
 }
 
 type Web3AuthResponse struct {
@@ -30,15 +32,36 @@ type Web3AuthResponse struct {
 	Message string `json:"message,omitempty"`
 }
 
+type LinkProxyAddressRequest struct {
+	ProxiedAddress string `json:"proxiedAddress"`
+	ProxyAddress   string `json:"proxyAddress"`
+	ProxyType      string `json:"proxyType,omitempty"`
+	Message        string `json:"message"`
+	Signature      string `json:"signature"`
+	Network        string `json:"network,omitempty"`
+}
+
+type LinkMultisigAddressRequest struct {
+	MultisigAddress string   `json:"multisigAddress"`
+	Signatory       string   `json:"signatory"`
+	Signatories     []string `json:"signatories"`
+	Threshold       int      `json:"threshold"`
+	Message         string   `json:"message"`
+	Signature       string   `json:"signature"`
+	Network         string   `json:"network,omitempty"`
+}
+
 type Web2LoginRequest struct {
 	EmailOrUsername string `json:"emailOrUsername"`
 	Password        string `json:"password"`
 }
 
 type Web2LoginResponse struct {
-	Token   string `json:"token"`
-	User    User   `json:"user"`
-	Message string `json:"message,omitempty"`
+	Token        string `json:"token"`
+	User         User   `json:"user"`
+	Message      string `json:"message,omitempty"`
+	IsTFAEnabled bool   `json:"isTFAEnabled,omitempty"`
+	TFAToken     string `json:"tfaToken,omitempty"`
 }
 
 type Web2SignupRequest struct {
@@ -68,6 +91,31 @@ type ClaimQRSessionRequest struct {
 	Address   string `json:"address,omitempty"`
 }
 
+type LinkAddressRequest struct {
+	Address   string `json:"address"`
+	Message   string `json:"message"`
+	Signature string `json:"signature"`
+	Wallet    string `json:"wallet,omitempty"`
+	Network   string `json:"network,omitempty"`
+}
+
+type LinkWeb2AccountRequest struct {
+	Username string `json:"username,omitempty"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type TwoFactorSetup struct {
+	Base32Secret string `json:"base32_secret"`
+	URL          string `json:"url"`
+}
+
+type Validate2FARequest struct {
+	TFAToken string `json:"tfaToken"`
+	AuthCode string `json:"authCode"`
+	LoginID  int    `json:"loginId,omitempty"`
+}
+
 type EditUserDetailsRequest struct {
 	Username          string             `json:"username,omitempty"`
 	Email             string             `json:"email,omitempty"`
@@ -86,14 +134,14 @@ type PublicSocialLink struct {
 
 // Post types
 type PostListingParams struct {
-	Page         int    `json:"page,omitempty"`
-	ListingLimit int    `json:"listingLimit,omitempty"`
-	TrackNo      int    `json:"trackNo,omitempty"`
-	TrackStatus  string `json:"trackStatus,omitempty"`
-	ProposalType string `json:"proposalType,omitempty"`
-	SortBy       string `json:"sortBy,omitempty"`
-	SearchTerm   string `json:"searchTerm,omitempty"`
-	Origin       string `json:"origin,omitempty"`
+	Page         int          `json:"page,omitempty"`
+	ListingLimit int          `json:"listingLimit,omitempty"`
+	TrackNo      int          `json:"trackNo,omitempty"`
+	TrackStatus  string       `json:"trackStatus,omitempty"`
+	ProposalType ProposalType `json:"proposalType,omitempty"`
+	SortBy       string       `json:"sortBy,omitempty"`
+	SearchTerm   string       `json:"searchTerm,omitempty"`
+	Origin       string       `json:"origin,omitempty"`
 }
 
 type PostListingResponse struct {
@@ -113,7 +161,7 @@ type Post struct {
 	CreatedAt        time.Time    `json:"createdAt"`
 	UpdatedAt        time.Time    `json:"updatedAt"`
 	PostType         string       `json:"post_type,omitempty"` // Legacy field
-	ProposalType     string       `json:"proposalType"`        // Actual field
+	ProposalType     ProposalType `json:"proposalType"`        // Actual field
 	Status           string       `json:"status,omitempty"`
 	ProposerAddress  string       `json:"proposer,omitempty"`
 	CommentsCount    int          `json:"comments_count,omitempty"`
@@ -131,11 +179,21 @@ type Post struct {
 	IsDeleted        bool         `json:"isDeleted"`
 	IsDefaultContent bool         `json:"isDefaultContent"`
 	Tags             []string     `json:"tags"`
+	TopicID          int          `json:"topicId,omitempty"`
+	LinkedPost       *LinkedPost  `json:"linkedPost,omitempty"`
+	LastCommentAt    time.Time    `json:"lastCommentAt,omitempty"`
 	Metrics          PostMetrics  `json:"metrics"`
 	OnChainInfo      *OnChainInfo `json:"onChainInfo,omitempty"`
 	PublicUser       *PublicUser  `json:"publicUser,omitempty"`
 }
 
+// LinkedPost references the post a discussion was converted into, or the
+// discussion an on-chain post was created from
+type LinkedPost struct {
+	ProposalType ProposalType `json:"proposalType"`
+	IndexOrHash  string       `json:"indexOrHash"`
+}
+
 type PostMetrics struct {
 	Reactions struct {
 		Like    int `json:"like"`
@@ -268,14 +326,44 @@ type SubscriptionStatus struct {
 }
 
 type Bounty struct {
-	BountyID       int    `json:"bounty_id"`
-	Description    string `json:"description"`
-	Proposer       string `json:"proposer"`
-	Value          string `json:"value"`
-	Fee            string `json:"fee"`
-	Status         string `json:"status"`
-	CuratorDeposit string `json:"curator_deposit,omitempty"`
-	Bond           string `json:"bond,omitempty"`
+	BountyID       int       `json:"bounty_id"`
+	Description    string    `json:"description"`
+	Proposer       string    `json:"proposer"`
+	Value          string    `json:"value"`
+	Fee            string    `json:"fee"`
+	Status         string    `json:"status"`
+	CuratorDeposit string    `json:"curator_deposit,omitempty"`
+	Bond           string    `json:"bond,omitempty"`
+	Curator        string    `json:"curator,omitempty"`
+	Beneficiary    string    `json:"beneficiary,omitempty"`
+	ParentBountyID int       `json:"parent_bounty_id,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	// StatusHistory lists the on-chain status changes, when the API provides them
+	StatusHistory []BountyStatusEvent `json:"status_history,omitempty"`
+}
+
+type BountyStatusEvent struct {
+	Status    string    `json:"status"`
+	Timestamp time.Time `json:"timestamp"`
+	Block     int       `json:"block,omitempty"`
+}
+
+type CreateBountyRequest struct {
+	Value       string `json:"value"`
+	Description string `json:"description"`
+	Title       string `json:"title"`
+	Content     string `json:"content"`
+	// ParentBountyID is set for child bounties
+	ParentBountyID int `json:"parent_bounty_id,omitempty"`
+}
+
+// BountyListingParams filters bounty and child bounty listings
+type BountyListingParams struct {
+	Page     int
+	Limit    int
+	Status   string
+	Proposer string
+	Curator  string
 }
 
 // Vote types
@@ -302,6 +390,15 @@ type Vote struct {
 	DelegatedTo     string    `json:"delegatedTo,omitempty"`
 	IsDelegated     bool      `json:"isDelegated"`
 	ConvictionCount int       `json:"conviction_count"`
+	AyeBalance      string    `json:"ayeBalance,omitempty"`
+	NayBalance      string    `json:"nayBalance,omitempty"`
+	AbstainBalance  string    `json:"abstainBalance,omitempty"`
+
+	// Derived from conviction by the client
+	EffectiveAye     string        `json:"effectiveAye,omitempty"`
+	EffectiveNay     string        `json:"effectiveNay,omitempty"`
+	EffectiveAbstain string        `json:"effectiveAbstain,omitempty"`
+	LockDuration     time.Duration `json:"lockDuration,omitempty"`
 }
 
 type VotingCurveData struct {
@@ -314,7 +411,7 @@ type VotingCurveData struct {
 
 type CreateVoteRequest struct {
 	PostID     int    `json:"postId"`
-	Vote       string `json:"vote"` // "aye" or "nay"
+	Vote       string `json:"vote"` // "aye", "nay" or "abstain"
 	Balance    string `json:"balance,omitempty"`
 	LockPeriod int    `json:"lockPeriod,omitempty"`
 }
@@ -338,21 +435,27 @@ type Reaction struct {
 }
 
 type Report struct {
-	ID         int       `json:"id"`
-	Type       string    `json:"type"`
-	ContentID  int       `json:"content_id"`
-	Reason     string    `json:"reason"`
-	Comments   string    `json:"comments"`
-	ReportedBy string    `json:"reported_by"`
-	CreatedAt  time.Time `json:"created_at"`
-	Status     string    `json:"status"`
-}
-
+	ID           int          `json:"id"`
+	Type         string       `json:"type"`
+	ContentID    int          `json:"content_id"`
+	ProposalType ProposalType `json:"proposal_type,omitempty"`
+	CommentID    string       `json:"comment_id,omitempty"`
+	Reason       string       `json:"reason"`
+	Comments     string       `json:"comments"`
+	ReportedBy   string       `json:"reported_by"`
+	CreatedAt    time.Time    `json:"created_at"`
+	Status       string       `json:"status"`
+}
+
+// CreateReportRequest reports a post or, when CommentID is set, a comment on
+// the post ContentID
 type CreateReportRequest struct {
-	Type      string `json:"type"`
-	ContentID int    `json:"content_id"`
-	Reason    string `json:"reason"`
-	Comments  string `json:"comments,omitempty"`
+	Type         string       `json:"type"`
+	ContentID    int          `json:"content_id"`
+	ProposalType ProposalType `json:"proposal_type,omitempty"`
+	CommentID    string       `json:"comment_id,omitempty"`
+	Reason       string       `json:"reason"`
+	Comments     string       `json:"comments,omitempty"`
 }
 
 // User types
@@ -400,6 +503,8 @@ type Preimage struct {
 	CreatedAt    time.Time   `json:"created_at"`
 	Author       string      `json:"author,omitempty"`
 	Deposit      string      `json:"deposit,omitempty"`
+	// Call is ProposedCall decoded by the client
+	Call *DecodedCall `json:"decodedCall,omitempty"`
 }
 
 type PreimageListingParams struct {
@@ -466,6 +571,30 @@ type Delegate struct {
 	CreatedAt        time.Time `json:"created_at"`
 	Image            string    `json:"image,omitempty"`
 	Score            int       `json:"score"`
+	// Sources lists the registries the delegate is listed in
+	Sources          DelegateSources        `json:"dataSource,omitempty"`
+	DelegatedBalance string                 `json:"delegated_balance,omitempty"`
+	TrackBalances    []DelegateTrackBalance `json:"track_balances,omitempty"`
+}
+
+// DelegateTrackBalance is the balance delegated to a delegate on one track
+type DelegateTrackBalance struct {
+	TrackID          int    `json:"trackId"`
+	DelegatedBalance string `json:"delegatedBalance"`
+	DelegationsCount int    `json:"delegationsCount"`
+}
+
+type DelegateListingParams struct {
+	Page  int
+	Limit int
+	// Sources keeps delegates listed in any of the given registries
+	Sources []DelegateSource
+	// Track keeps delegates with delegations on the track when non-nil
+	Track  *int
+	Search string
+	SortBy DelegateSort
+	// Ascending reverses the default descending order
+	Ascending bool
 }
 
 type CreatePADelegateRequest struct {
@@ -474,7 +603,26 @@ type CreatePADelegateRequest struct {
 }
 
 type UpdatePADelegateRequest struct {
-	Manifesto string `json:"manifesto"`
+	Manifesto string `json:"manifesto,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Bio       string `json:"bio,omitempty"`
+	Image     string `json:"image,omitempty"`
+}
+
+// TrackDelegation is a delegation between two accounts on a track
+type TrackDelegation struct {
+	Delegator  string     `json:"from"`
+	Delegate   string     `json:"to"`
+	Balance    string     `json:"balance"`
+	Conviction Conviction `json:"lockPeriod"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// TrackDelegations are the delegations an address received and gave on a track
+type TrackDelegations struct {
+	TrackID  int               `json:"trackId"`
+	Received []TrackDelegation `json:"receivedDelegations"`
+	Given    []TrackDelegation `json:"delegatedTo"`
 }
 
 type TrackStats struct {
@@ -501,6 +649,15 @@ type TreasuryProposal struct {
 	CreatedAt   time.Time `json:"created_at"`
 }
 
+// TreasuryListingParams filters treasury proposal and tip listings. For tips
+// Proposer matches the finder.
+type TreasuryListingParams struct {
+	Page     int
+	Limit    int
+	Status   string
+	Proposer string
+}
+
 type CreateTreasuryProposalRequest struct {
 	Value       string `json:"value"`
 	Beneficiary string `json:"beneficiary"`
@@ -532,25 +689,38 @@ type CreateTipRequest struct {
 
 // Discussion types
 type Discussion struct {
-	ID            int       `json:"id"`
-	Title         string    `json:"title"`
-	Content       string    `json:"content"`
-	Author        string    `json:"author"`
-	Tags          []string  `json:"tags"`
-	ViewCount     int       `json:"view_count"`
-	CommentCount  int       `json:"comment_count"`
-	ReactionCount int       `json:"reaction_count"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
-	LastCommentAt time.Time `json:"last_comment_at,omitempty"`
+	ID            int         `json:"id"`
+	Title         string      `json:"title"`
+	Content       string      `json:"content"`
+	Author        string      `json:"author"`
+	Tags          []string    `json:"tags"`
+	TopicID       int         `json:"topic_id,omitempty"`
+	ViewCount     int         `json:"view_count"`
+	CommentCount  int         `json:"comment_count"`
+	ReactionCount int         `json:"reaction_count"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+	LastCommentAt time.Time   `json:"last_comment_at,omitempty"`
+	LinkedPost    *LinkedPost `json:"linked_post,omitempty"`
 }
 
 type CreateDiscussionRequest struct {
 	Title   string   `json:"title"`
 	Content string   `json:"content"`
+	TopicID int      `json:"topic_id,omitempty"`
 	Tags    []string `json:"tags,omitempty"`
 }
 
+// DiscussionListingParams filters discussion listings. A discussion must
+// carry all of Tags to match.
+type DiscussionListingParams struct {
+	Page    int
+	Limit   int
+	TopicID int
+	Tags    []string
+	SortBy  string
+}
+
 // Poll types
 type Poll struct {
 	ID         int          `json:"id"`
@@ -592,6 +762,12 @@ type Notification struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type NotificationListingParams struct {
+	Page       int
+	Limit      int
+	UnreadOnly bool
+}
+
 type NotificationPreferences struct {
 	NewProposal          bool `json:"new_proposal"`
 	ProposalStatusChange bool `json:"proposal_status_change"`
@@ -613,6 +789,9 @@ type ProposalAnalytics struct {
 	DailyStats     []DailyStat    `json:"daily_stats"`
 	VoterBreakdown map[string]int `json:"voter_breakdown"`
 	TopCommenters  []UserStat     `json:"top_commenters"`
+	// Approximate is set when the analytics were computed client-side from
+	// comments and votes because the server endpoint was unavailable
+	Approximate bool `json:"approximate,omitempty"`
 }
 
 type DailyStat struct {
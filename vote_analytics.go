@@ -0,0 +1,318 @@
+package polkassembly
+
+import (
+	"encoding/json"
+	"math/big"
+	"sort"
+)
+
+const votePageSize = 100
+
+// VoteAnalyticsOptions configures AnalyzeVotes
+type VoteAnalyticsOptions struct {
+	// TopN is the number of top voters reported (default 10)
+	TopN int
+	// TotalIssuance enables TurnoutPercent when set
+	TotalIssuance string
+}
+
+// VoteBucket sums a group of votes. Capital is the balance voted and
+// Effective the conviction-weighted power.
+type VoteBucket struct {
+	Count     int    `json:"count"`
+	Capital   string `json:"capital"`
+	Effective string `json:"effective"`
+}
+
+// VoterPower is the combined voting power of one address
+type VoterPower struct {
+	Address     string `json:"address"`
+	Decision    string `json:"decision"`
+	Conviction  int    `json:"conviction"`
+	Capital     string `json:"capital"`
+	Effective   string `json:"effective"`
+	IsDelegated bool   `json:"isDelegated"`
+}
+
+// VoteAnalytics summarizes all votes of a referendum
+type VoteAnalytics struct {
+	PostID         int                   `json:"postId,omitempty"`
+	ProposalType   ProposalType          `json:"proposalType,omitempty"`
+	TotalVotes     int                   `json:"totalVotes"`
+	UniqueVoters   int                   `json:"uniqueVoters"`
+	ByDecision     map[string]VoteBucket `json:"byDecision"`
+	ByConviction   map[string]VoteBucket `json:"byConviction"`
+	Direct         VoteBucket            `json:"direct"`
+	Delegated      VoteBucket            `json:"delegated"`
+	TopVoters      []VoterPower          `json:"topVoters"`
+	Gini           float64               `json:"gini"`
+	Nakamoto       int                   `json:"nakamoto"`
+	Turnout        string                `json:"turnout"`
+	TurnoutPercent float64               `json:"turnoutPercent,omitempty"`
+	// Unparsed counts votes skipped because their balance or conviction could
+	// not be parsed; they are left out of every other field
+	Unparsed int `json:"unparsed,omitempty"`
+}
+
+// JSON exports the analytics as indented JSON
+func (a *VoteAnalytics) JSON() ([]byte, error) {
+	return json.MarshalIndent(a, "", "  ")
+}
+
+// GetAllVotes pages through every vote on a post. When the server reports a
+// count, pages are fetched until that many votes arrive or a page is empty,
+// so a server capping the page size below votePageSize is still read in full.
+func (c *Client) GetAllVotes(postID int, proposalType ProposalType) ([]Vote, error) {
+	var votes []Vote
+	for page := 1; ; page++ {
+		resp, err := c.GetVotesByType(VoteListingParams{
+			PostID: postID,
+			Page:   page,
+			Limit:  votePageSize,
+		}, proposalType)
+		if err != nil {
+			return nil, err
+		}
+
+		votes = append(votes, resp.Votes...)
+		if len(resp.Votes) == 0 {
+			return votes, nil
+		}
+		if resp.Count > 0 {
+			if len(votes) >= resp.Count {
+				return votes, nil
+			}
+		} else if len(resp.Votes) < votePageSize {
+			return votes, nil
+		}
+	}
+}
+
+// GetVoteAnalytics fetches all votes of a post and summarizes them
+func (c *Client) GetVoteAnalytics(postID int, proposalType ProposalType, opts VoteAnalyticsOptions) (*VoteAnalytics, error) {
+	if proposalType == "" {
+		proposalType = ProposalTypeReferendumV2
+	}
+
+	votes, err := c.GetAllVotes(postID, proposalType)
+	if err != nil {
+		return nil, err
+	}
+
+	analytics, err := AnalyzeVotes(votes, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	analytics.PostID = postID
+	analytics.ProposalType = proposalType
+	return analytics, nil
+}
+
+// bucket accumulates a VoteBucket
+type bucket struct {
+	count     int
+	capital   *big.Int
+	effective *big.Int
+}
+
+func newBucket() *bucket {
+	return &bucket{capital: new(big.Int), effective: new(big.Int)}
+}
+
+func (b *bucket) add(capital, effective *big.Int) {
+	b.count++
+	b.capital.Add(b.capital, capital)
+	b.effective.Add(b.effective, effective)
+}
+
+func (b *bucket) result() VoteBucket {
+	return VoteBucket{Count: b.count, Capital: b.capital.String(), Effective: b.effective.String()}
+}
+
+// AnalyzeVotes summarizes votes by decision, conviction and delegation, and
+// measures how concentrated the voting power is. Effective power counts
+// conviction-weighted aye and nay plus abstain at 0.1x. Votes that cannot be
+// parsed are skipped and counted in Unparsed.
+func AnalyzeVotes(votes []Vote, opts VoteAnalyticsOptions) (*VoteAnalytics, error) {
+	if opts.TopN <= 0 {
+		opts.TopN = 10
+	}
+
+	byDecision := make(map[string]*bucket)
+	byConviction := make(map[string]*bucket)
+	direct, delegated := newBucket(), newBucket()
+	turnout := new(big.Int)
+
+	type voter struct {
+		power     VoterPower
+		capital   *big.Int
+		effective *big.Int
+	}
+	voters := make(map[string]*voter)
+	unparsed := 0
+
+	for _, v := range votes {
+		w, err := v.Weight()
+		if err != nil {
+			unparsed++
+			continue
+		}
+		capital, err := voteCapital(v)
+		if err != nil {
+			unparsed++
+			continue
+		}
+
+		effective := new(big.Int).Add(w.Aye, w.Nay)
+		effective.Add(effective, EffectiveVotes(w.Abstain, ConvictionNone))
+
+		decision := v.decision()
+		if byDecision[decision] == nil {
+			byDecision[decision] = newBucket()
+		}
+		byDecision[decision].add(capital, effective)
+
+		conviction := v.Conviction().String()
+		if byConviction[conviction] == nil {
+			byConviction[conviction] = newBucket()
+		}
+		byConviction[conviction].add(capital, effective)
+
+		if v.IsDelegated {
+			delegated.add(capital, effective)
+		} else {
+			direct.add(capital, effective)
+		}
+
+		turnout.Add(turnout, capital)
+
+		vt, ok := voters[v.Voter]
+		if !ok {
+			vt = &voter{
+				power: VoterPower{
+					Address:     v.Voter,
+					Decision:    decision,
+					Conviction:  int(v.Conviction()),
+					IsDelegated: v.IsDelegated,
+				},
+				capital:   new(big.Int),
+				effective: new(big.Int),
+			}
+			voters[v.Voter] = vt
+		}
+		vt.capital.Add(vt.capital, capital)
+		vt.effective.Add(vt.effective, effective)
+	}
+
+	analytics := &VoteAnalytics{
+		TotalVotes:   len(votes) - unparsed,
+		Unparsed:     unparsed,
+		UniqueVoters: len(voters),
+		ByDecision:   make(map[string]VoteBucket),
+		ByConviction: make(map[string]VoteBucket),
+		Direct:       direct.result(),
+		Delegated:    delegated.result(),
+		Turnout:      turnout.String(),
+	}
+	for k, b := range byDecision {
+		analytics.ByDecision[k] = b.result()
+	}
+	for k, b := range byConviction {
+		analytics.ByConviction[k] = b.result()
+	}
+
+	ranked := make([]*voter, 0, len(voters))
+	for _, vt := range voters {
+		ranked = append(ranked, vt)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if c := ranked[i].effective.Cmp(ranked[j].effective); c != 0 {
+			return c > 0
+		}
+		return ranked[i].power.Address < ranked[j].power.Address
+	})
+
+	powers := make([]*big.Int, len(ranked))
+	for i, vt := range ranked {
+		powers[i] = vt.effective
+		if i < opts.TopN {
+			vt.power.Capital = vt.capital.String()
+			vt.power.Effective = vt.effective.String()
+			analytics.TopVoters = append(analytics.TopVoters, vt.power)
+		}
+	}
+	analytics.Gini = gini(powers)
+	analytics.Nakamoto = nakamoto(powers)
+
+	if opts.TotalIssuance != "" {
+		issuance, err := ParseBalance(opts.TotalIssuance)
+		if err != nil {
+			return nil, err
+		}
+		analytics.TurnoutPercent = ratio(turnout, issuance) * 100
+	}
+
+	return analytics, nil
+}
+
+// voteCapital is the balance of a vote across aye, nay and abstain
+func voteCapital(v Vote) (*big.Int, error) {
+	amounts := v.Amounts()
+	capital := new(big.Int)
+	for _, s := range []string{amounts.Aye, amounts.Nay, amounts.Abstain} {
+		b, err := ParseBalance(s)
+		if err != nil {
+			return nil, err
+		}
+		capital.Add(capital, b)
+	}
+	return capital, nil
+}
+
+// gini computes the Gini coefficient of values, 0 for perfect equality
+func gini(values []*big.Int) float64 {
+	n := len(values)
+	if n == 0 {
+		return 0
+	}
+
+	sorted := append([]*big.Int(nil), values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Cmp(sorted[j]) < 0 })
+
+	total := new(big.Int)
+	weighted := new(big.Int)
+	for i, v := range sorted {
+		total.Add(total, v)
+		weighted.Add(weighted, new(big.Int).Mul(v, big.NewInt(int64(i+1))))
+	}
+	if total.Sign() == 0 {
+		return 0
+	}
+
+	// G = 2 * sum(i * x_i) / (n * sum(x)) - (n + 1) / n
+	g := 2*ratio(weighted, new(big.Int).Mul(total, big.NewInt(int64(n)))) - float64(n+1)/float64(n)
+	return clamp(g, 0, 1)
+}
+
+// nakamoto returns the smallest number of voters holding more than half of
+// the total power. values must be sorted in descending order.
+func nakamoto(values []*big.Int) int {
+	total := new(big.Int)
+	for _, v := range values {
+		total.Add(total, v)
+	}
+	if total.Sign() == 0 {
+		return 0
+	}
+
+	half := new(big.Int).Quo(total, big.NewInt(2))
+	sum := new(big.Int)
+	for i, v := range values {
+		sum.Add(sum, v)
+		if sum.Cmp(half) > 0 {
+			return i + 1
+		}
+	}
+	return len(values)
+}
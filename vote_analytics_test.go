@@ -0,0 +1,89 @@
+package polkassembly
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestAnalyzeVotes(t *testing.T) {
+	votes := []Vote{
+		{Voter: "whale", Decision: DecisionAye, Balance: "1000", LockPeriod: 1},
+		{Voter: "small", Decision: DecisionNay, Balance: "100", LockPeriod: 0},
+		{Voter: "delegator", Decision: DecisionAye, Balance: "100", LockPeriod: 2, IsDelegated: true},
+		{Voter: "fence", Decision: DecisionSplitAbstain, AyeBalance: "0", NayBalance: "0", AbstainBalance: "500"},
+		{Voter: "broken", Decision: DecisionAye, Balance: "lots", LockPeriod: 1},
+	}
+
+	a, err := AnalyzeVotes(votes, VoteAnalyticsOptions{TopN: 2, TotalIssuance: "10000"})
+	if err != nil {
+		t.Fatalf("AnalyzeVotes failed: %v", err)
+	}
+
+	if a.TotalVotes != 4 || a.Unparsed != 1 {
+		t.Errorf("expected 4 votes and 1 unparsed, got %d and %d", a.TotalVotes, a.Unparsed)
+	}
+	if a.ByDecision[DecisionAye].Effective != "1200" || a.ByDecision[DecisionAye].Count != 2 {
+		t.Errorf("unexpected aye bucket: %+v", a.ByDecision[DecisionAye])
+	}
+	if a.Delegated.Capital != "100" || a.Direct.Count != 3 {
+		t.Errorf("unexpected delegation split: %+v %+v", a.Direct, a.Delegated)
+	}
+	if len(a.TopVoters) != 2 || a.TopVoters[0].Address != "whale" {
+		t.Errorf("unexpected top voters: %+v", a.TopVoters)
+	}
+	if a.Nakamoto != 1 {
+		t.Errorf("expected nakamoto 1, got %d", a.Nakamoto)
+	}
+	if a.Turnout != "1700" || math.Abs(a.TurnoutPercent-17) > 1e-9 {
+		t.Errorf("unexpected turnout: %s %f", a.Turnout, a.TurnoutPercent)
+	}
+	if a.Gini <= 0 || a.Gini >= 1 {
+		t.Errorf("unexpected gini: %f", a.Gini)
+	}
+
+	data, err := a.JSON()
+	if err != nil || !json.Valid(data) {
+		t.Errorf("JSON export failed: %v", err)
+	}
+}
+
+func TestGetAllVotesCappedPages(t *testing.T) {
+	const total, capped = 45, 20
+	all := make([]Vote, total)
+	for i := range all {
+		all[i] = Vote{ID: strconv.Itoa(i), Decision: DecisionAye, Balance: "1"}
+	}
+
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ReferendumV2/9/votes" {
+			http.NotFound(w, r)
+			return
+		}
+		pages = append(pages, r.URL.Query().Get("page"))
+		if r.URL.Query().Get("limit") != strconv.Itoa(votePageSize) {
+			t.Errorf("unexpected limit %q", r.URL.Query().Get("limit"))
+		}
+		// The server ignores the requested limit and caps every page
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		start, end := pageBounds(total, page, capped)
+		json.NewEncoder(w).Encode(VoteListingResponse{Votes: all[start:end], Count: total})
+	}))
+	defer server.Close()
+
+	c := NewClient(Config{BaseURL: server.URL, Network: "polkadot"})
+	votes, err := c.GetAllVotes(9, ProposalTypeReferendumV2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(votes) != total || votes[total-1].ID != strconv.Itoa(total-1) {
+		t.Errorf("expected all %d votes, got %d", total, len(votes))
+	}
+	if len(pages) != 3 {
+		t.Errorf("expected 3 pages, got %v", pages)
+	}
+}
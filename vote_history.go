@@ -0,0 +1,252 @@
+package polkassembly
+
+import (
+	"fmt"
+	"time"
+)
+
+// Referendum outcomes
+const (
+	OutcomePassed  = "passed"
+	OutcomeFailed  = "failed"
+	OutcomeOngoing = "ongoing"
+)
+
+// ReferendumOutcome classifies an on-chain referendum status
+func ReferendumOutcome(status string) string {
+	switch status {
+	case "Approved", "Confirmed", "Executed", "ExecutionFailed":
+		return OutcomePassed
+	case "Rejected", "TimedOut", "Cancelled", "Killed":
+		return OutcomeFailed
+	default:
+		return OutcomeOngoing
+	}
+}
+
+// VoteHistoryParams selects the referenda to scan. Either a track or an index
+// range (or both) should be given.
+type VoteHistoryParams struct {
+	Address      string
+	ProposalType ProposalType
+	// Track restricts the scan to one track when non-nil
+	Track *int
+	// FromIndex and ToIndex bound the referendum index range, inclusive. A zero
+	// ToIndex means no upper bound when Track is set.
+	FromIndex int
+	ToIndex   int
+	// IncludeDelegators also collects votes delegated to Address
+	IncludeDelegators bool
+}
+
+// VoteRecord is a single vote cast on a referendum
+type VoteRecord struct {
+	PostID      int       `json:"postId"`
+	Title       string    `json:"title"`
+	TrackNo     int       `json:"trackNo"`
+	Status      string    `json:"status"`
+	Outcome     string    `json:"outcome"`
+	Voter       string    `json:"voter"`
+	Decision    string    `json:"decision"`
+	Conviction  int       `json:"conviction"`
+	Balance     string    `json:"balance"`
+	IsDelegated bool      `json:"isDelegated"`
+	DelegatedTo string    `json:"delegatedTo,omitempty"`
+	VotedAt     time.Time `json:"votedAt,omitempty"`
+	// Aligned is nil for abstain and split votes or ongoing referenda
+	Aligned *bool `json:"aligned,omitempty"`
+}
+
+// VoteHistory is the voting record of an address across referenda
+type VoteHistory struct {
+	Address           string       `json:"address"`
+	Records           []VoteRecord `json:"records"`
+	Referenda         int          `json:"referenda"`
+	Voted             int          `json:"voted"`
+	ParticipationRate float64      `json:"participationRate"`
+	Aligned           int          `json:"aligned"`
+	Counted           int          `json:"counted"`
+	AlignmentRate     float64      `json:"alignmentRate"`
+	DelegatorVotes    int          `json:"delegatorVotes"`
+	// Skipped lists the referenda of an index range that could not be loaded
+	Skipped []int `json:"skipped,omitempty"`
+}
+
+// GetVoteHistory compiles the votes an address (and optionally its
+// delegators) cast across a range of referenda or a track, with how often the
+// address voted with the final outcome
+func (c *Client) GetVoteHistory(params VoteHistoryParams) (*VoteHistory, error) {
+	if params.Address == "" {
+		return nil, fmt.Errorf("address is required")
+	}
+	if params.ProposalType == "" {
+		params.ProposalType = ProposalTypeReferendumV2
+	}
+
+	posts, skipped, err := c.historyPosts(params)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &VoteHistory{Address: params.Address, Skipped: skipped}
+	for i := range posts {
+		post := &posts[i]
+		history.Referenda++
+
+		votes, err := c.addressVotes(params, post.Index)
+		if err != nil {
+			return nil, err
+		}
+
+		voted := false
+		for _, v := range votes {
+			record := newVoteRecord(post, v)
+			if SameAccount(v.Voter, params.Address) {
+				voted = true
+				if record.Aligned != nil {
+					history.Counted++
+					if *record.Aligned {
+						history.Aligned++
+					}
+				}
+			} else {
+				history.DelegatorVotes++
+			}
+			history.Records = append(history.Records, record)
+		}
+		if voted {
+			history.Voted++
+		}
+	}
+
+	if history.Referenda > 0 {
+		history.ParticipationRate = float64(history.Voted) / float64(history.Referenda)
+	}
+	if history.Counted > 0 {
+		history.AlignmentRate = float64(history.Aligned) / float64(history.Counted)
+	}
+
+	return history, nil
+}
+
+// historyPosts lists the referenda selected by params. In range mode it also
+// returns the indices it could not load.
+func (c *Client) historyPosts(params VoteHistoryParams) ([]Post, []int, error) {
+	inRange := func(index int) bool {
+		return index >= params.FromIndex && (params.ToIndex == 0 || index <= params.ToIndex)
+	}
+
+	if params.Track == nil {
+		if params.ToIndex < params.FromIndex || params.ToIndex == 0 {
+			return nil, nil, fmt.Errorf("a track or an index range is required")
+		}
+
+		var posts []Post
+		var skipped []int
+		for index := params.FromIndex; index <= params.ToIndex; index++ {
+			post, err := c.GetPostByType(index, params.ProposalType)
+			if err != nil {
+				c.logDebug("Skipping referendum %d: %v", index, err)
+				skipped = append(skipped, index)
+				continue
+			}
+			if post.Index == 0 {
+				post.Index = index
+			}
+			posts = append(posts, *post)
+		}
+		return posts, skipped, nil
+	}
+
+	listing := PostListingParams{
+		ProposalType: params.ProposalType,
+		TrackNo:      *params.Track,
+		ListingLimit: 50,
+	}
+	// Track 0 is only sent when resolved through its origin
+	if track, err := TrackByID(c.network, *params.Track); err == nil {
+		listing.Origin = track.Origin
+	}
+
+	var posts []Post
+	for page := 1; ; page++ {
+		listing.Page = page
+		resp, err := c.GetPosts(listing)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, post := range resp.Posts {
+			if post.TrackNumber == 0 {
+				post.TrackNumber = *params.Track
+			}
+			if inRange(post.Index) {
+				posts = append(posts, post)
+			}
+		}
+
+		if len(resp.Posts) < listing.ListingLimit || (resp.TotalCount > 0 && page*listing.ListingLimit >= resp.TotalCount) {
+			return posts, nil, nil
+		}
+	}
+}
+
+// addressVotes returns the votes of the address on a post, plus those of its
+// delegators when requested
+func (c *Client) addressVotes(params VoteHistoryParams, postID int) ([]Vote, error) {
+	if !params.IncludeDelegators {
+		resp, err := c.GetVotesByAddress(params.ProposalType, postID, params.Address, 1, votePageSize)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Votes, nil
+	}
+
+	all, err := c.GetAllVotes(postID, params.ProposalType)
+	if err != nil {
+		return nil, err
+	}
+
+	var votes []Vote
+	for _, v := range all {
+		if SameAccount(v.Voter, params.Address) || (v.DelegatedTo != "" && SameAccount(v.DelegatedTo, params.Address)) {
+			votes = append(votes, v)
+		}
+	}
+	return votes, nil
+}
+
+func newVoteRecord(post *Post, v Vote) VoteRecord {
+	record := VoteRecord{
+		PostID:      post.Index,
+		Title:       post.Title,
+		TrackNo:     post.TrackNumber,
+		Status:      post.Status,
+		Voter:       v.Voter,
+		Decision:    v.decision(),
+		Conviction:  int(v.Conviction()),
+		Balance:     v.Balance,
+		IsDelegated: v.IsDelegated,
+		DelegatedTo: v.DelegatedTo,
+		VotedAt:     v.CreatedAt,
+	}
+	if post.OnChainInfo != nil && post.OnChainInfo.Status != "" {
+		record.Status = post.OnChainInfo.Status
+	}
+	record.Outcome = ReferendumOutcome(record.Status)
+
+	if record.Outcome != OutcomeOngoing {
+		var aligned bool
+		switch record.Decision {
+		case DecisionAye:
+			aligned = record.Outcome == OutcomePassed
+		case DecisionNay:
+			aligned = record.Outcome == OutcomeFailed
+		default:
+			return record
+		}
+		record.Aligned = &aligned
+	}
+
+	return record
+}
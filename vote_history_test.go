@@ -0,0 +1,155 @@
+package polkassembly
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestNewVoteRecord(t *testing.T) {
+	post := &Post{Index: 42, OnChainInfo: &OnChainInfo{Status: "Executed"}}
+
+	aye := newVoteRecord(post, Vote{Voter: aliceAddress, Decision: DecisionAye, Balance: "10"})
+	if aye.Outcome != OutcomePassed || aye.Aligned == nil || !*aye.Aligned {
+		t.Errorf("expected aligned aye on passed referendum: %+v", aye)
+	}
+
+	nay := newVoteRecord(post, Vote{Voter: aliceAddress, Decision: DecisionNay, Balance: "10"})
+	if nay.Aligned == nil || *nay.Aligned {
+		t.Errorf("expected misaligned nay on passed referendum: %+v", nay)
+	}
+
+	post.OnChainInfo.Status = "Deciding"
+	ongoing := newVoteRecord(post, Vote{Voter: aliceAddress, Decision: DecisionAye})
+	if ongoing.Aligned != nil {
+		t.Errorf("ongoing referendum should not count towards alignment: %+v", ongoing)
+	}
+}
+
+// newVoteHistoryServer serves the referenda in posts and the votes in votes by
+// referendum index. Referenda without votes have none.
+func newVoteHistoryServer(t *testing.T, posts []Post, votes map[int][]Vote) (*Client, *[]string) {
+	t.Helper()
+
+	var listings []string
+	byPath := make(map[string]interface{})
+	for _, p := range posts {
+		byPath["/ReferendumV2/"+strconv.Itoa(p.Index)] = p
+	}
+	for index, vs := range votes {
+		byPath["/ReferendumV2/"+strconv.Itoa(index)+"/votes"] = VoteListingResponse{Votes: vs, Count: len(vs)}
+		for _, v := range vs {
+			if !v.IsDelegated {
+				byPath["/ReferendumV2/"+strconv.Itoa(index)+"/votes/user/address/"+v.Voter] = VoteListingResponse{Votes: []Vote{v}}
+			}
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/ReferendumV2" {
+			listings = append(listings, r.URL.RawQuery)
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+			start, end := pageBounds(len(posts), page, limit)
+			json.NewEncoder(w).Encode(PostListingResponse{Items: posts[start:end], TotalCount: len(posts)})
+			return
+		}
+		body, ok := byPath[r.URL.Path]
+		if !ok && strings.Contains(r.URL.Path, "/votes") {
+			body, ok = VoteListingResponse{}, true
+		}
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(server.Close)
+
+	return NewClient(Config{BaseURL: server.URL, Network: "polkadot"}), &listings
+}
+
+func TestGetVoteHistoryRange(t *testing.T) {
+	posts := []Post{
+		{Index: 1, OnChainInfo: &OnChainInfo{Status: "Executed"}},
+		{Index: 3, OnChainInfo: &OnChainInfo{Status: "Rejected"}},
+		{Index: 4, OnChainInfo: &OnChainInfo{Status: "Deciding"}},
+	}
+	c, _ := newVoteHistoryServer(t, posts, map[int][]Vote{
+		1: {{Voter: aliceAddress, Decision: DecisionAye, Balance: "10"}},
+		3: {{Voter: aliceAddress, Decision: DecisionAye, Balance: "10"}},
+	})
+
+	history, err := c.GetVoteHistory(VoteHistoryParams{Address: aliceAddress, FromIndex: 1, ToIndex: 4})
+	if err != nil {
+		t.Fatalf("GetVoteHistory failed: %v", err)
+	}
+
+	// Referendum 2 does not exist; 4 is ongoing and was not voted on
+	if len(history.Skipped) != 1 || history.Skipped[0] != 2 {
+		t.Errorf("expected referendum 2 skipped, got %v", history.Skipped)
+	}
+	if history.Referenda != 3 || history.Voted != 2 || len(history.Records) != 2 {
+		t.Errorf("unexpected counts: %+v", history)
+	}
+	if math.Abs(history.ParticipationRate-2.0/3) > 1e-9 {
+		t.Errorf("expected participation 2/3, got %v", history.ParticipationRate)
+	}
+	if history.Counted != 2 || history.Aligned != 1 || history.AlignmentRate != 0.5 {
+		t.Errorf("expected one of two votes aligned, got %d/%d (%v)", history.Aligned, history.Counted, history.AlignmentRate)
+	}
+}
+
+func TestGetVoteHistoryTrack(t *testing.T) {
+	var posts []Post
+	for index := 100; index <= 150; index++ {
+		posts = append(posts, Post{Index: index, TrackNumber: 33, OnChainInfo: &OnChainInfo{Status: "Executed"}})
+	}
+	c, listings := newVoteHistoryServer(t, posts, map[int][]Vote{
+		150: {
+			{Voter: aliceAddress, Decision: DecisionNay, Balance: "10"},
+			{Voter: bobAddress, Decision: DecisionNay, Balance: "5", IsDelegated: true, DelegatedTo: aliceAddress},
+			{Voter: charlieAddress, Decision: DecisionAye, Balance: "20"},
+		},
+	})
+
+	track := 33
+	history, err := c.GetVoteHistory(VoteHistoryParams{
+		Address:           aliceAddress,
+		Track:             &track,
+		FromIndex:         149,
+		IncludeDelegators: true,
+	})
+	if err != nil {
+		t.Fatalf("GetVoteHistory failed: %v", err)
+	}
+
+	if len(*listings) != 2 {
+		t.Fatalf("expected two listing pages, got %v", *listings)
+	}
+	for _, query := range *listings {
+		if q, _ := url.ParseQuery(query); q.Get("trackNo") != "33" || q.Get("origin") != "MediumSpender" || q.Get("limit") != "50" {
+			t.Errorf("unexpected listing query %q", query)
+		}
+	}
+
+	if history.Referenda != 2 || history.Voted != 1 || history.ParticipationRate != 0.5 {
+		t.Errorf("expected one of two referenda voted, got %+v", history)
+	}
+	if history.DelegatorVotes != 1 || len(history.Records) != 2 {
+		t.Errorf("expected the vote of bob as delegator, got %+v", history.Records)
+	}
+	if history.Counted != 1 || history.Aligned != 0 || history.AlignmentRate != 0 {
+		t.Errorf("expected the nay on an executed referendum misaligned, got %d/%d", history.Aligned, history.Counted)
+	}
+	for _, r := range history.Records {
+		if r.PostID != 150 || r.TrackNo != 33 {
+			t.Errorf("unexpected record: %+v", r)
+		}
+	}
+}
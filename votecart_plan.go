@@ -0,0 +1,262 @@
+package polkassembly
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// CartIssue explains why a cart item cannot be submitted
+type CartIssue struct {
+	ItemID          string `json:"itemId"`
+	PostIndexOrHash string `json:"postIndexOrHash"`
+	Reason          string `json:"reason"`
+}
+
+func (i CartIssue) Error() string {
+	return fmt.Sprintf("cart item %s (referendum %s): %s", i.ItemID, i.PostIndexOrHash, i.Reason)
+}
+
+// CartReferendumState is the current state of a referendum a cart item votes on
+type CartReferendumState struct {
+	Status string
+	// Voted is true if the voter already has a vote on the referendum
+	Voted bool
+}
+
+type CartValidationOptions struct {
+	// Voter is checked for existing votes; required by Client.ValidateCart
+	Voter string
+	// Available is the voter's free balance. When set, no item may vote more.
+	Available string
+}
+
+// CartValidation splits a cart into submittable items, duplicates and issues
+type CartValidation struct {
+	Valid []CartItem `json:"valid"`
+	// Duplicates are older items voting on the same referendum as a kept item
+	Duplicates []CartItem  `json:"duplicates"`
+	Issues     []CartIssue `json:"issues"`
+}
+
+// OK reports whether every item can be submitted as is
+func (v *CartValidation) OK() bool {
+	return len(v.Issues) == 0 && len(v.Duplicates) == 0
+}
+
+// ValidateCartItems checks cart items against the state of their referenda,
+// keyed by referendum index. Items are deduplicated per referendum, keeping
+// the most recently created one.
+func ValidateCartItems(items []CartItem, states map[int]CartReferendumState, opts CartValidationOptions) (*CartValidation, error) {
+	var available *big.Int
+	if opts.Available != "" {
+		var err error
+		if available, err = ParseBalance(opts.Available); err != nil {
+			return nil, fmt.Errorf("available balance: %w", err)
+		}
+	}
+
+	ordered := append([]CartItem(nil), items...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].CreatedAt.After(ordered[j].CreatedAt)
+	})
+
+	v := &CartValidation{}
+	seen := make(map[int]bool)
+	for _, item := range ordered {
+		issue := func(reason string, args ...interface{}) {
+			v.Issues = append(v.Issues, CartIssue{
+				ItemID:          item.ID,
+				PostIndexOrHash: item.PostIndexOrHash,
+				Reason:          fmt.Sprintf(reason, args...),
+			})
+		}
+
+		if item.ProposalType != "" && ProposalType(item.ProposalType) != ProposalTypeReferendumV2 {
+			issue("only OpenGov referenda can be voted through convictionVoting, got %s", item.ProposalType)
+			continue
+		}
+
+		index, err := item.PostIndex()
+		if err != nil {
+			issue("%v", err)
+			continue
+		}
+		if seen[index] {
+			v.Duplicates = append(v.Duplicates, item)
+			continue
+		}
+		seen[index] = true
+
+		if reason := checkCartVote(item, available); reason != "" {
+			issue("%s", reason)
+			continue
+		}
+
+		state, ok := states[index]
+		switch {
+		case !ok:
+			issue("referendum not found")
+			continue
+		case state.Status == "" || ReferendumOutcome(state.Status) != OutcomeOngoing:
+			issue("referendum is not open for voting (status %q)", state.Status)
+			continue
+		case state.Voted:
+			issue("already voted on this referendum")
+			continue
+		}
+
+		v.Valid = append(v.Valid, item)
+	}
+
+	// Keep the cart's order for the valid items
+	sort.SliceStable(v.Valid, func(i, j int) bool {
+		return v.Valid[i].CreatedAt.Before(v.Valid[j].CreatedAt)
+	})
+	return v, nil
+}
+
+// checkCartVote checks conviction and amounts of an item, returning the
+// reason it is invalid or an empty string
+func checkCartVote(item CartItem, available *big.Int) string {
+	conviction := Conviction(item.Conviction)
+	if !conviction.Valid() {
+		return fmt.Sprintf("conviction must be between %d and %d, got %d", ConvictionNone, ConvictionLocked6x, item.Conviction)
+	}
+
+	amounts := map[string]*big.Int{}
+	for name, s := range map[string]string{"aye": item.Amount.Aye, "nay": item.Amount.Nay, "abstain": item.Amount.Abstain} {
+		b, err := ParseBalance(s)
+		if err != nil {
+			return fmt.Sprintf("%s amount: %v", name, err)
+		}
+		if b.Sign() < 0 {
+			return fmt.Sprintf("%s amount is negative", name)
+		}
+		amounts[name] = b
+	}
+
+	var required []string
+	total := new(big.Int)
+	switch item.Decision {
+	case DecisionAye:
+		required = []string{"aye"}
+	case DecisionNay:
+		required = []string{"nay"}
+	case DecisionSplit:
+		required = []string{"aye", "nay"}
+	case DecisionAbstain, DecisionSplitAbstain:
+		required = []string{"abstain"}
+	default:
+		return fmt.Sprintf("unknown vote decision: %q", item.Decision)
+	}
+
+	if item.Decision != DecisionAye && item.Decision != DecisionNay && conviction != ConvictionNone {
+		return fmt.Sprintf("%s votes cannot carry conviction", item.Decision)
+	}
+
+	for _, name := range required {
+		if amounts[name].Sign() == 0 {
+			return fmt.Sprintf("%s amount is required for a %s vote", name, item.Decision)
+		}
+	}
+	for _, b := range amounts {
+		total.Add(total, b)
+	}
+	if available != nil && total.Cmp(available) > 0 {
+		return fmt.Sprintf("amount %s exceeds available balance %s", total, available)
+	}
+	return ""
+}
+
+// CartSubmissionPlan is a validated cart ready to be submitted as a single
+// utility.batchAll of convictionVoting.vote calls
+type CartSubmissionPlan struct {
+	Call       Call            `json:"call"`
+	Items      []CartItem      `json:"items"`
+	Validation *CartValidation `json:"validation"`
+}
+
+// PlanCartSubmission builds the batch call for items, which should already be validated
+func PlanCartSubmission(items []CartItem) (*CartSubmissionPlan, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no cart items to submit")
+	}
+
+	calls := make([]Call, 0, len(items))
+	for _, item := range items {
+		call, err := item.VoteCall()
+		if err != nil {
+			return nil, fmt.Errorf("cart item %s: %w", item.ID, err)
+		}
+		calls = append(calls, call)
+	}
+
+	return &CartSubmissionPlan{Call: BatchAll(calls...), Items: items}, nil
+}
+
+// ValidateCart fetches the cart of a user and validates it against the
+// current state of each referendum
+func (c *Client) ValidateCart(userID int, opts CartValidationOptions) (*CartValidation, error) {
+	if opts.Voter == "" {
+		return nil, fmt.Errorf("voter address is required")
+	}
+
+	items, err := c.GetCartItems(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make(map[int]CartReferendumState)
+	for _, item := range items {
+		index, err := item.PostIndex()
+		if err != nil {
+			continue
+		}
+		if _, ok := states[index]; ok {
+			continue
+		}
+
+		post, err := c.GetPostByType(index, ProposalTypeReferendumV2)
+		if err != nil {
+			c.logDebug("Skipping cart referendum %d: %v", index, err)
+			continue
+		}
+		state := CartReferendumState{Status: post.Status}
+		if post.OnChainInfo != nil && post.OnChainInfo.Status != "" {
+			state.Status = post.OnChainInfo.Status
+		}
+
+		votes, err := c.GetVotesByAddress(ProposalTypeReferendumV2, index, opts.Voter, 1, 1)
+		if err != nil {
+			return nil, err
+		}
+		for _, vote := range votes.Votes {
+			if SameAccount(vote.Voter, opts.Voter) {
+				state.Voted = true
+			}
+		}
+
+		states[index] = state
+	}
+
+	return ValidateCartItems(items, states, opts)
+}
+
+// PlanCart validates the cart of a user and plans the submission of its valid items
+func (c *Client) PlanCart(userID int, opts CartValidationOptions) (*CartSubmissionPlan, error) {
+	validation, err := c.ValidateCart(userID, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(validation.Valid) == 0 {
+		return nil, fmt.Errorf("no valid cart items: %d issues", len(validation.Issues))
+	}
+
+	plan, err := PlanCartSubmission(validation.Valid)
+	if err != nil {
+		return nil, err
+	}
+	plan.Validation = validation
+	return plan, nil
+}
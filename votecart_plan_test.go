@@ -0,0 +1,78 @@
+package polkassembly
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidateCartItems(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	items := []CartItem{
+		{ID: "a", PostIndexOrHash: "10", Decision: DecisionAye, Amount: CartAmount{Aye: "100"}, Conviction: 1, CreatedAt: t0},
+		{ID: "b", PostIndexOrHash: "10", Decision: DecisionNay, Amount: CartAmount{Nay: "50"}, Conviction: 2, CreatedAt: t0.Add(time.Hour)},
+		{ID: "c", PostIndexOrHash: "11", Decision: DecisionSplit, Amount: CartAmount{Aye: "1", Nay: "2"}, Conviction: 3},
+		{ID: "d", PostIndexOrHash: "12", Decision: DecisionAye, Amount: CartAmount{Aye: "10"}, Conviction: 9},
+		{ID: "e", PostIndexOrHash: "13", Decision: DecisionAye, Amount: CartAmount{Aye: "10"}},
+		{ID: "f", PostIndexOrHash: "14", Decision: DecisionAbstain, Amount: CartAmount{Abstain: "5000"}},
+		{ID: "g", PostIndexOrHash: "15", Decision: DecisionAbstain, Amount: CartAmount{Abstain: "5"}},
+		{ID: "h", PostIndexOrHash: "16", Decision: DecisionAye, Amount: CartAmount{Aye: "5"}},
+	}
+	states := map[int]CartReferendumState{
+		10: {Status: "Deciding"},
+		11: {Status: "Deciding"},
+		12: {Status: "Deciding"},
+		13: {Status: "Executed"},
+		14: {Status: "Deciding"},
+		15: {Status: "Confirming"},
+		16: {Status: "Deciding", Voted: true},
+	}
+
+	v, err := ValidateCartItems(items, states, CartValidationOptions{Available: "1000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(v.Valid) != 2 || v.Valid[0].ID != "g" || v.Valid[1].ID != "b" {
+		t.Errorf("unexpected valid items: %+v", v.Valid)
+	}
+	if len(v.Duplicates) != 1 || v.Duplicates[0].ID != "a" {
+		t.Errorf("expected the older item on referendum 10 as duplicate: %+v", v.Duplicates)
+	}
+
+	issues := make(map[string]bool)
+	for _, issue := range v.Issues {
+		issues[issue.ItemID] = true
+	}
+	for _, id := range []string{"c", "d", "e", "f", "h"} {
+		if !issues[id] {
+			t.Errorf("expected an issue for item %s, got %+v", id, v.Issues)
+		}
+	}
+}
+
+func TestPlanCartSubmission(t *testing.T) {
+	plan, err := PlanCartSubmission([]CartItem{
+		{ID: "a", PostIndexOrHash: "10", Decision: DecisionAye, Amount: CartAmount{Aye: "0x64"}, Conviction: 1},
+		{ID: "b", PostIndexOrHash: "11", Decision: DecisionAbstain, Amount: CartAmount{Abstain: "5"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if plan.Call.String() != "utility.batchAll" {
+		t.Fatalf("expected utility.batchAll, got %s", plan.Call)
+	}
+	calls := plan.Call.Args.(BatchCallArgs).Calls
+	if len(calls) != 2 || calls[0].String() != "convictionVoting.vote" {
+		t.Fatalf("unexpected calls: %+v", calls)
+	}
+
+	aye := calls[0].Args.(VoteCallArgs)
+	if aye.PollIndex != 10 || aye.Vote.Standard == nil || !aye.Vote.Standard.Aye || aye.Vote.Standard.Balance != "100" {
+		t.Errorf("unexpected aye vote: %+v", aye)
+	}
+	abstain := calls[1].Args.(VoteCallArgs)
+	if abstain.Vote.SplitAbstain == nil || abstain.Vote.SplitAbstain.Abstain != "5" || abstain.Vote.SplitAbstain.Aye != "0" {
+		t.Errorf("unexpected abstain vote: %+v", abstain)
+	}
+}
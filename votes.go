@@ -6,13 +6,14 @@ import (
 
 // GetVotes retrieves votes for a specific proposal
 func (c *Client) GetVotes(params VoteListingParams) (*VoteListingResponse, error) {
-	return c.GetVotesByType(params, "ReferendumV2")
+	return c.GetVotesByType(params, ProposalTypeReferendumV2)
 }
 
 // GetVotesByType retrieves votes for a specific proposal type
-func (c *Client) GetVotesByType(params VoteListingParams, proposalType string) (*VoteListingResponse, error) {
-	if proposalType == "" {
-		proposalType = "ReferendumV2"
+func (c *Client) GetVotesByType(params VoteListingParams, proposalType ProposalType) (*VoteListingResponse, error) {
+	path, err := proposalPath(proposalType, ProposalTypeReferendumV2)
+	if err != nil {
+		return nil, err
 	}
 
 	queryParams := make(map[string]string)
@@ -26,7 +27,7 @@ func (c *Client) GetVotesByType(params VoteListingParams, proposalType string) (
 		queryParams["decision"] = params.Decision
 	}
 
-	endpoint := fmt.Sprintf("/%s/%d/votes", proposalType, params.PostID)
+	endpoint := fmt.Sprintf("/%s/%d/votes", path, params.PostID)
 
 	r, err := c.client.R().
 		SetQueryParams(queryParams).
@@ -40,13 +41,15 @@ func (c *Client) GetVotesByType(params VoteListingParams, proposalType string) (
 		return nil, err
 	}
 
+	c.applyConviction(resp.Votes)
 	return &resp, nil
 }
 
 // GetVotesByAddress retrieves votes by a specific address
-func (c *Client) GetVotesByAddress(proposalType string, postID int, address string, page, limit int) (*VoteListingResponse, error) {
-	if proposalType == "" {
-		proposalType = "ReferendumV2"
+func (c *Client) GetVotesByAddress(proposalType ProposalType, postID int, address string, page, limit int) (*VoteListingResponse, error) {
+	path, err := proposalPath(proposalType, ProposalTypeReferendumV2)
+	if err != nil {
+		return nil, err
 	}
 
 	queryParams := map[string]string{}
@@ -59,7 +62,7 @@ func (c *Client) GetVotesByAddress(proposalType string, postID int, address stri
 
 	r, err := c.client.R().
 		SetQueryParams(queryParams).
-		Get(fmt.Sprintf("/%s/%d/votes/user/address/%s", proposalType, postID, address))
+		Get(fmt.Sprintf("/%s/%d/votes/user/address/%s", path, postID, address))
 	if err != nil {
 		return nil, err
 	}
@@ -69,13 +72,15 @@ func (c *Client) GetVotesByAddress(proposalType string, postID int, address stri
 		return nil, err
 	}
 
+	c.applyConviction(resp.Votes)
 	return &resp, nil
 }
 
 // GetVotesByUserID retrieves votes by a specific user ID
-func (c *Client) GetVotesByUserID(proposalType string, postID int, userID int, page, limit int) (*VoteListingResponse, error) {
-	if proposalType == "" {
-		proposalType = "ReferendumV2"
+func (c *Client) GetVotesByUserID(proposalType ProposalType, postID int, userID int, page, limit int) (*VoteListingResponse, error) {
+	path, err := proposalPath(proposalType, ProposalTypeReferendumV2)
+	if err != nil {
+		return nil, err
 	}
 
 	queryParams := map[string]string{}
@@ -88,7 +93,7 @@ func (c *Client) GetVotesByUserID(proposalType string, postID int, userID int, p
 
 	r, err := c.client.R().
 		SetQueryParams(queryParams).
-		Get(fmt.Sprintf("/%s/%d/votes/user/id/%d", proposalType, postID, userID))
+		Get(fmt.Sprintf("/%s/%d/votes/user/id/%d", path, postID, userID))
 	if err != nil {
 		return nil, err
 	}
@@ -98,22 +103,24 @@ func (c *Client) GetVotesByUserID(proposalType string, postID int, userID int, p
 		return nil, err
 	}
 
+	c.applyConviction(resp.Votes)
 	return &resp, nil
 }
 
 // GetVotingCurve retrieves voting curve data for a proposal
 func (c *Client) GetVotingCurve(postID int) ([]VotingCurveData, error) {
-	return c.GetVotingCurveByType(postID, "ReferendumV2")
+	return c.GetVotingCurveByType(postID, ProposalTypeReferendumV2)
 }
 
 // GetVotingCurveByType retrieves voting curve data for a specific proposal type
-func (c *Client) GetVotingCurveByType(postID int, proposalType string) ([]VotingCurveData, error) {
-	if proposalType == "" {
-		proposalType = "ReferendumV2"
+func (c *Client) GetVotingCurveByType(postID int, proposalType ProposalType) ([]VotingCurveData, error) {
+	path, err := proposalPath(proposalType, ProposalTypeReferendumV2)
+	if err != nil {
+		return nil, err
 	}
 
 	r, err := c.client.R().
-		Get(fmt.Sprintf("/%s/%d/vote-curves", proposalType, postID))
+		Get(fmt.Sprintf("/%s/%d/vote-curves", path, postID))
 	if err != nil {
 		return nil, err
 	}
@@ -20,26 +20,40 @@ type Web3SignResponse struct {
 	Error   string `json:"error,omitempty"`
 }
 
-// AuthenticateWithSigner authenticates using a signer
+// AuthenticateWithSigner authenticates using a signer. An *OnBehalfSigner logs
+// in with its delegate key for the proxied or multisig account and links that
+// account to the user, so comments can be attributed to it via
+// AddCommentRequest.Address.
 func (c *Client) AuthenticateWithSigner(network string, signer Signer) error {
 	// Generate a message to sign
-	message := fmt.Sprintf("Sign this message to authenticate with Polkassembly\n\nNetwork: %s\nAddress: %s\nTimestamp: %d",
-		network, signer.Address(), time.Now().Unix())
-
-	// Sign the message
-	signature, err := signer.Sign([]byte(message))
-	if err != nil {
-		return fmt.Errorf("sign message: %w", err)
-	}
+	message := authMessage(network, signer.Address())
 
 	// Create auth request
 	req := Web3AuthRequest{
-		Address:   signer.Address(),
-		Signature: "0x" + hex.EncodeToString(signature),
-		Message:   message,
-		Network:   network,
+		Address: signer.Address(),
+		Message: message,
+		Network: network,
+	}
+
+	onBehalf, isOnBehalf := signer.(*OnBehalfSigner)
+	if isOnBehalf {
+		switch onBehalf.Kind {
+		case AccountKindProxy:
+			req.ProxiedAddress = onBehalf.Account
+		case AccountKindMultisig:
+			req.MultisigAddress = onBehalf.Account
+		default:
+			return fmt.Errorf("unknown account kind: %s", onBehalf.Kind)
+		}
 	}
 
+	// Sign the message
+	signature, err := signMessage(signer, message)
+	if err != nil {
+		return err
+	}
+	req.Signature = signature
+
 	// Authenticate
 	resp, err := c.Web3Auth(req)
 	if err != nil {
@@ -51,24 +65,107 @@ func (c *Client) AuthenticateWithSigner(network string, signer Signer) error {
 		c.SetAuthToken(resp.Token)
 	}
 
+	if isOnBehalf && !SameAccount(resp.User.Web3Address, onBehalf.Account) {
+		if err := c.LinkOnBehalfAccount(network, onBehalf); err != nil {
+			return fmt.Errorf("link %s address: %w", onBehalf.Kind, err)
+		}
+	}
+
 	return nil
 }
 
-// AuthenticateWithSeed authenticates using a seed phrase
-func (c *Client) AuthenticateWithSeed(network string, seedPhrase string) error {
-	// Determine network ID for SS58 encoding
-	var networkID uint16
-	switch network {
-	case "polkadot":
-		networkID = 0
-	case "kusama":
-		networkID = 2
-	default:
-		networkID = 42
+// LinkOnBehalfAccount links the proxied or multisig account of signer to the
+// authenticated user, proving control with the delegate's signature
+func (c *Client) LinkOnBehalfAccount(network string, signer *OnBehalfSigner) error {
+	if signer.Kind != AccountKindProxy && signer.Kind != AccountKindMultisig {
+		return fmt.Errorf("unknown account kind: %s", signer.Kind)
+	}
+
+	message := linkMessage(network, signer.Account, signer.Address())
+
+	signature, err := signMessage(signer, message)
+	if err != nil {
+		return err
 	}
 
+	if signer.Kind == AccountKindProxy {
+		return c.LinkProxyAddress(LinkProxyAddressRequest{
+			ProxiedAddress: signer.Account,
+			ProxyAddress:   signer.Address(),
+			ProxyType:      signer.ProxyType,
+			Message:        message,
+			Signature:      signature,
+			Network:        network,
+		})
+	}
+	return c.LinkMultisigAddress(LinkMultisigAddressRequest{
+		MultisigAddress: signer.Account,
+		Signatory:       signer.Address(),
+		Signatories:     signer.Signatories,
+		Threshold:       signer.Threshold,
+		Message:         message,
+		Signature:       signature,
+		Network:         network,
+	})
+}
+
+// LinkProxyAddress links a proxied account to the authenticated user
+func (c *Client) LinkProxyAddress(req LinkProxyAddressRequest) error {
+	if req.Network == "" {
+		req.Network = c.network
+	}
+
+	r, err := c.client.R().
+		SetBody(req).
+		Post("/auth/link-proxy-address")
+
+	if err != nil {
+		return err
+	}
+
+	return c.parseResponse(r, nil)
+}
+
+// LinkMultisigAddress links a multisig account to the authenticated user
+func (c *Client) LinkMultisigAddress(req LinkMultisigAddressRequest) error {
+	if req.Network == "" {
+		req.Network = c.network
+	}
+
+	r, err := c.client.R().
+		SetBody(req).
+		Post("/auth/link-multisig-address")
+
+	if err != nil {
+		return err
+	}
+
+	return c.parseResponse(r, nil)
+}
+
+func authMessage(network, address string) string {
+	return fmt.Sprintf("Sign this message to authenticate with Polkassembly\n\nNetwork: %s\nAddress: %s\nTimestamp: %d",
+		network, address, time.Now().Unix())
+}
+
+func linkMessage(network, account, signer string) string {
+	return fmt.Sprintf("Sign this message to link %s to your Polkassembly account\n\nNetwork: %s\nSigner: %s\nTimestamp: %d",
+		account, network, signer, time.Now().Unix())
+}
+
+// signMessage signs message and returns the 0x-prefixed hex signature
+func signMessage(signer Signer, message string) (string, error) {
+	signature, err := signer.Sign([]byte(message))
+	if err != nil {
+		return "", fmt.Errorf("sign message: %w", err)
+	}
+	return "0x" + hex.EncodeToString(signature), nil
+}
+
+// AuthenticateWithSeed authenticates using a seed phrase
+func (c *Client) AuthenticateWithSeed(network string, seedPhrase string) error {
 	// Create signer
-	signer, err := NewPolkadotSignerFromSeed(seedPhrase, networkID)
+	signer, err := NewPolkadotSignerFromSeed(seedPhrase, ss58Prefix(network))
 	if err != nil {
 		return fmt.Errorf("create signer: %w", err)
 	}
@@ -0,0 +1,109 @@
+package polkassembly
+
+import (
+	"net/http"
+	"testing"
+)
+
+// countingSigner records how many messages it signed
+type countingSigner struct {
+	fixedSigner
+	signed int
+}
+
+func (s *countingSigner) Sign(message []byte) ([]byte, error) {
+	s.signed++
+	return s.fixedSigner.Sign(message)
+}
+
+func TestAuthenticateWithProxySigner(t *testing.T) {
+	c, requests := newAccountTestClient(t, map[string]stubResponse{
+		"POST /auth/web3-auth": {Status: http.StatusOK, Body: Web3AuthResponse{Token: "token"}},
+	})
+
+	signer := NewProxySigner(fixedSigner{address: aliceAddress}, bobAddress, "Governance")
+	if err := c.AuthenticateWithSigner("polkadot", signer); err != nil {
+		t.Fatalf("AuthenticateWithSigner failed: %v", err)
+	}
+
+	if len(*requests) != 2 {
+		t.Fatalf("expected auth and link requests, got %+v", *requests)
+	}
+	auth := (*requests)[0]
+	if auth.Path != "/auth/web3-auth" || auth.Body["address"] != aliceAddress || auth.Body["proxiedAddress"] != bobAddress {
+		t.Errorf("unexpected auth request: %+v", auth)
+	}
+	if _, hasMultisig := auth.Body["multisigAddress"]; hasMultisig {
+		t.Error("expected no multisig address for a proxy")
+	}
+
+	link := (*requests)[1]
+	if link.Path != "/auth/link-proxy-address" || link.Authorization != "token" {
+		t.Errorf("unexpected link request: %+v", link)
+	}
+	if link.Body["proxiedAddress"] != bobAddress || link.Body["proxyAddress"] != aliceAddress || link.Body["proxyType"] != "Governance" || link.Body["signature"] != "0xabcd" {
+		t.Errorf("unexpected link body: %+v", link.Body)
+	}
+}
+
+func TestAuthenticateWithMultisigSigner(t *testing.T) {
+	signer, err := NewMultisigSigner(fixedSigner{address: aliceAddress}, []string{aliceAddress, bobAddress}, 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, requests := newAccountTestClient(t, map[string]stubResponse{
+		"POST /auth/web3-auth": {Status: http.StatusOK, Body: Web3AuthResponse{Token: "token"}},
+	})
+
+	if err := c.AuthenticateWithSigner("polkadot", signer); err != nil {
+		t.Fatalf("AuthenticateWithSigner failed: %v", err)
+	}
+
+	if len(*requests) != 2 {
+		t.Fatalf("expected auth and link requests, got %+v", *requests)
+	}
+	if auth := (*requests)[0]; auth.Body["multisigAddress"] != signer.Account {
+		t.Errorf("unexpected auth body: %+v", auth.Body)
+	}
+	link := (*requests)[1]
+	if link.Path != "/auth/link-multisig-address" || link.Body["multisigAddress"] != signer.Account || link.Body["signatory"] != aliceAddress {
+		t.Errorf("unexpected link request: %+v", link)
+	}
+	if link.Body["threshold"] != float64(2) || len(link.Body["signatories"].([]interface{})) != 2 {
+		t.Errorf("unexpected link body: %+v", link.Body)
+	}
+}
+
+func TestAuthenticateWithLinkedAccount(t *testing.T) {
+	c, requests := newAccountTestClient(t, map[string]stubResponse{
+		"POST /auth/web3-auth": {Status: http.StatusOK, Body: Web3AuthResponse{
+			Token: "token",
+			User:  User{ID: 5, Web3Address: bobAddress},
+		}},
+	})
+
+	// The proxied account is already the user's address, so it is not linked again
+	signer := NewProxySigner(fixedSigner{address: aliceAddress}, bobAddress, "Governance")
+	if err := c.AuthenticateWithSigner("polkadot", signer); err != nil {
+		t.Fatalf("AuthenticateWithSigner failed: %v", err)
+	}
+	if len(*requests) != 1 || (*requests)[0].Path != "/auth/web3-auth" {
+		t.Errorf("expected only the auth request, got %+v", *requests)
+	}
+}
+
+func TestAuthenticateWithUnknownAccountKind(t *testing.T) {
+	c, requests := newAccountTestClient(t, nil)
+
+	delegate := &countingSigner{fixedSigner: fixedSigner{address: aliceAddress}}
+	signer := &OnBehalfSigner{Signer: delegate, Account: bobAddress, Kind: "pure"}
+	if err := c.AuthenticateWithSigner("polkadot", signer); err == nil {
+		t.Error("expected an error for an unknown account kind")
+	}
+	if err := c.LinkOnBehalfAccount("polkadot", signer); err == nil {
+		t.Error("expected an error for an unknown account kind")
+	}
+	if delegate.signed != 0 || len(*requests) != 0 {
+		t.Errorf("expected nothing signed or sent, got %d signatures and %d requests", delegate.signed, len(*requests))
+	}
+}